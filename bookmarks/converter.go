@@ -1,6 +1,8 @@
 package bookmarks
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
 	"html"
 	"io"
@@ -26,11 +28,29 @@ func (hw *HTMLWriter) WriteIndented(indent int, text string) error {
 }
 
 // HTMLConverter handles conversion of bookmark data to HTML format
-type HTMLConverter struct{}
+type HTMLConverter struct {
+	faviconFetcher FaviconFetcher
+}
+
+// HTMLConverterOption configures an HTMLConverter created by
+// NewHTMLConverter.
+type HTMLConverterOption func(*HTMLConverter)
+
+// WithFavicons enables inline ICON= embedding: writeBookmark resolves each
+// bookmark's favicon via fetcher and embeds it as a base64 data: URI.
+func WithFavicons(fetcher FaviconFetcher) HTMLConverterOption {
+	return func(hc *HTMLConverter) {
+		hc.faviconFetcher = fetcher
+	}
+}
 
 // NewHTMLConverter creates a new HTMLConverter
-func NewHTMLConverter() *HTMLConverter {
-	return &HTMLConverter{}
+func NewHTMLConverter(opts ...HTMLConverterOption) *HTMLConverter {
+	hc := &HTMLConverter{}
+	for _, opt := range opts {
+		opt(hc)
+	}
+	return hc
 }
 
 // htmlEscape escapes HTML special characters to prevent XSS and display issues
@@ -70,6 +90,23 @@ func (hc *HTMLConverter) formatDateAttributes(data *BookmarkData) string {
 	return strings.Join(attributes, "")
 }
 
+// formatFaviconAttribute resolves data's favicon via the configured
+// FaviconFetcher, if any, and formats it as a base64 data: URI ICON
+// attribute. It returns "" if WithFavicons wasn't used, data has no URI, or
+// no favicon could be resolved.
+func (hc *HTMLConverter) formatFaviconAttribute(data *BookmarkData) string {
+	if hc.faviconFetcher == nil || data.URI == "" {
+		return ""
+	}
+
+	icon, err := hc.faviconFetcher.Favicon(context.Background(), data.URI)
+	if err != nil || len(icon) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(` ICON="data:image/png;base64,%s"`, base64.StdEncoding.EncodeToString(icon))
+}
+
 // writeHTMLHeader writes the HTML document header
 func (hc *HTMLConverter) writeHTMLHeader(writer *HTMLWriter, title string) error {
 	header := fmt.Sprintf(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
@@ -105,9 +142,10 @@ func (hc *HTMLConverter) writeBookmark(writer *HTMLWriter, data *BookmarkData, i
 	}
 	title = hc.htmlEscape(title)
 	dateAttrs := hc.formatDateAttributes(data)
+	faviconAttr := hc.formatFaviconAttribute(data)
 
 	err := writer.WriteIndented(indent,
-		fmt.Sprintf(`<DT><A HREF="%s"%s>%s</A>`, hc.htmlEscape(uri), dateAttrs, title))
+		fmt.Sprintf(`<DT><A HREF="%s"%s%s>%s</A>`, hc.htmlEscape(uri), dateAttrs, faviconAttr, title))
 	if err != nil {
 		return err
 	}
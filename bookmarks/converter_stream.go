@@ -0,0 +1,243 @@
+package bookmarks
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sync"
+)
+
+// bufioWriterPool reuses *bufio.Writer instances across ConvertToHTMLStream
+// calls, avoiding a fresh allocation for every export.
+var bufioWriterPool = sync.Pool{
+	New: func() interface{} {
+		return bufio.NewWriterSize(io.Discard, 64*1024)
+	},
+}
+
+// htmlStreamFrame is one pending step in the iterative tree walk performed
+// by ConvertToHTMLStream: either a node still to be written, or a marker to
+// emit a folder's closing </DL><p> once all of its children have been.
+type htmlStreamFrame struct {
+	data    *BookmarkData
+	indent  int
+	isClose bool
+}
+
+// writeIndentStream writes indent*IndentSize spaces to w.
+func writeIndentStream(w *bufio.Writer, indent int) error {
+	for i := 0; i < IndentSize*indent; i++ {
+		if err := w.WriteByte(' '); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEscapedStream HTML-escapes s directly into w, one rune at a time,
+// instead of building an intermediate escaped string.
+func writeEscapedStream(w *bufio.Writer, s string) error {
+	for _, r := range s {
+		var replacement string
+		switch r {
+		case '&':
+			replacement = "&amp;"
+		case '\'':
+			replacement = "&#39;"
+		case '<':
+			replacement = "&lt;"
+		case '>':
+			replacement = "&gt;"
+		case '"':
+			replacement = "&#34;"
+		default:
+			if _, err := w.WriteRune(r); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := w.WriteString(replacement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConvertToHTMLStream renders root as Netscape Bookmark File HTML into w.
+// Unlike ConvertToHTML, it walks the tree iteratively with an explicit
+// stack instead of recursing per node, writes directly into a pooled
+// *bufio.Writer instead of building one fmt.Sprintf string per line, and
+// checks ctx between nodes so exports of very large trees (tens of
+// thousands of bookmarks) can be cancelled without finishing.
+func (hc *HTMLConverter) ConvertToHTMLStream(ctx context.Context, w io.Writer, root *BookmarkData) error {
+	bw := bufioWriterPool.Get().(*bufio.Writer)
+	bw.Reset(w)
+	defer func() {
+		bw.Reset(io.Discard)
+		bufioWriterPool.Put(bw)
+	}()
+
+	stack := []htmlStreamFrame{{data: root, indent: 0}}
+
+	for len(stack) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		frame := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if frame.isClose {
+			if err := writeIndentStream(bw, frame.indent); err != nil {
+				return err
+			}
+			if _, err := bw.WriteString("</DL><p>\n"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data := frame.data
+		switch {
+		case data.Children != nil:
+			if frame.indent == 0 {
+				title := data.Title
+				if title == "" {
+					title = "Bookmarks Menu"
+				}
+				if err := hc.writeHTMLHeaderStream(bw, title); err != nil {
+					return err
+				}
+			} else if err := hc.writeFolderStream(bw, data, frame.indent); err != nil {
+				return err
+			}
+
+			// Push the closing marker before the children so it pops (and
+			// writes) only after all of them have been processed.
+			stack = append(stack, htmlStreamFrame{indent: frame.indent, isClose: true})
+
+			for i := len(data.Children) - 1; i >= 0; i-- {
+				child := data.Children[i]
+				if child.TypeCode == BookmarkSeparatorType {
+					continue
+				}
+				stack = append(stack, htmlStreamFrame{data: &child, indent: frame.indent + 1})
+			}
+
+		case data.URI != "":
+			if err := hc.writeBookmarkStream(bw, data, frame.indent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeHTMLHeaderStream writes the HTML document header directly into bw.
+func (hc *HTMLConverter) writeHTMLHeaderStream(bw *bufio.Writer, title string) error {
+	const header = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+    It will be read and overwritten.
+    DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>`
+
+	if _, err := bw.WriteString(header); err != nil {
+		return err
+	}
+	if err := writeEscapedStream(bw, title); err != nil {
+		return err
+	}
+	_, err := bw.WriteString("</H1>\n<DL><p>\n")
+	return err
+}
+
+// writeFolderStream writes a folder's <DT><H3>...</H3> and opening <DL><p>
+// directly into bw.
+func (hc *HTMLConverter) writeFolderStream(bw *bufio.Writer, data *BookmarkData, indent int) error {
+	if err := writeIndentStream(bw, indent); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString("<DT><H3"); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(hc.formatDateAttributes(data)); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(">"); err != nil {
+		return err
+	}
+	if err := writeEscapedStream(bw, data.Title); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString("</H3>\n"); err != nil {
+		return err
+	}
+
+	if err := writeIndentStream(bw, indent); err != nil {
+		return err
+	}
+	_, err := bw.WriteString("<DL><p>\n")
+	return err
+}
+
+// writeBookmarkStream writes a single <DT><A ...>...</A> (and its <DD>
+// description, if any) directly into bw.
+func (hc *HTMLConverter) writeBookmarkStream(bw *bufio.Writer, data *BookmarkData, indent int) error {
+	title := data.Title
+	if title == "" {
+		title = data.URI
+	}
+
+	if err := writeIndentStream(bw, indent); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(`<DT><A HREF="`); err != nil {
+		return err
+	}
+	if err := writeEscapedStream(bw, data.URI); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(`"`); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(hc.formatDateAttributes(data)); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(hc.formatFaviconAttribute(data)); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(">"); err != nil {
+		return err
+	}
+	if err := writeEscapedStream(bw, title); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString("</A>\n"); err != nil {
+		return err
+	}
+
+	for _, anno := range data.Annotations {
+		if anno.Name != "bookmarkProperties/description" {
+			continue
+		}
+		if err := writeIndentStream(bw, indent); err != nil {
+			return err
+		}
+		if _, err := bw.WriteString("<DD>"); err != nil {
+			return err
+		}
+		if err := writeEscapedStream(bw, anno.Value); err != nil {
+			return err
+		}
+		if _, err := bw.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
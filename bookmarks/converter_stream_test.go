@@ -0,0 +1,159 @@
+package bookmarks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHTMLConverter_ConvertToHTMLStream(t *testing.T) {
+	converter := NewHTMLConverter()
+
+	testData := &BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title: "Development",
+				Children: []BookmarkData{
+					{
+						Title: "GitHub",
+						URI:   "https://github.com",
+					},
+					{
+						Title: "Stack Overflow",
+						URI:   "https://stackoverflow.com",
+					},
+				},
+			},
+			{
+				Title: "Example Bookmark",
+				URI:   "https://example.com",
+				Annotations: []Annotation{
+					{Name: "bookmarkProperties/description", Value: "This is an example"},
+				},
+			},
+			{
+				TypeCode: BookmarkSeparatorType, // Should be skipped
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := converter.ConvertToHTMLStream(context.Background(), &buf, testData); err != nil {
+		t.Fatalf("ConvertToHTMLStream failed: %v", err)
+	}
+
+	result := buf.String()
+
+	expectedParts := []string{
+		"<!DOCTYPE NETSCAPE-Bookmark-file-1>",
+		"<H1>Bookmarks Menu</H1>",
+		"<DT><H3>Development</H3>",
+		`<DT><A HREF="https://github.com">GitHub</A>`,
+		`<DT><A HREF="https://stackoverflow.com">Stack Overflow</A>`,
+		`<DT><A HREF="https://example.com">Example Bookmark</A>`,
+		"<DD>This is an example",
+		"</DL><p>",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(result, part) {
+			t.Errorf("Expected output to contain %q, but it didn't.\nFull output:\n%s", part, result)
+		}
+	}
+
+	if strings.Contains(result, "separator") {
+		t.Error("Output should not contain separator elements")
+	}
+}
+
+// TestHTMLConverter_ConvertToHTMLStream_MatchesConvertToHTML proves the
+// streaming writer and the recursive one produce byte-identical output for
+// the same tree.
+func TestHTMLConverter_ConvertToHTMLStream_MatchesConvertToHTML(t *testing.T) {
+	converter := NewHTMLConverter()
+	testData := &BookmarkData{
+		Title: "Tom & Jerry's <Bookmarks>",
+		Children: []BookmarkData{
+			{
+				Title: "Folder",
+				Children: []BookmarkData{
+					{Title: "Example", URI: "https://example.com?q=1&r=2", DateAdded: 1639123456789000},
+				},
+			},
+			{TypeCode: BookmarkSeparatorType},
+		},
+	}
+
+	var recursive bytes.Buffer
+	if err := converter.ConvertToHTML(NewHTMLWriter(&recursive), testData, 0); err != nil {
+		t.Fatalf("ConvertToHTML failed: %v", err)
+	}
+
+	var streamed bytes.Buffer
+	if err := converter.ConvertToHTMLStream(context.Background(), &streamed, testData); err != nil {
+		t.Fatalf("ConvertToHTMLStream failed: %v", err)
+	}
+
+	if recursive.String() != streamed.String() {
+		t.Errorf("ConvertToHTMLStream output differs from ConvertToHTML:\nrecursive:\n%s\nstreamed:\n%s", recursive.String(), streamed.String())
+	}
+}
+
+func TestHTMLConverter_ConvertToHTMLStream_ContextCancellation(t *testing.T) {
+	converter := NewHTMLConverter()
+	children := make([]BookmarkData, 1000)
+	for i := range children {
+		children[i] = BookmarkData{Title: fmt.Sprintf("Bookmark %d", i), URI: fmt.Sprintf("https://example.com/%d", i)}
+	}
+	testData := &BookmarkData{Title: "Bookmarks Menu", Children: children}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	err := converter.ConvertToHTMLStream(ctx, &buf, testData)
+	if err != context.Canceled {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func largeBookmarkTree(count int) *BookmarkData {
+	children := make([]BookmarkData, count)
+	for i := range children {
+		children[i] = BookmarkData{
+			Title: fmt.Sprintf("Bookmark %d", i),
+			URI:   fmt.Sprintf("https://example.com/%d", i),
+		}
+	}
+	return &BookmarkData{Title: "Bookmarks Menu", Children: children}
+}
+
+func BenchmarkHTMLConverter_ConvertToHTML(b *testing.B) {
+	converter := NewHTMLConverter()
+	data := largeBookmarkTree(100000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var buf bytes.Buffer
+		if err := converter.ConvertToHTML(NewHTMLWriter(&buf), data, 0); err != nil {
+			b.Fatalf("ConvertToHTML failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkHTMLConverter_ConvertToHTMLStream(b *testing.B) {
+	converter := NewHTMLConverter()
+	data := largeBookmarkTree(100000)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var buf bytes.Buffer
+		if err := converter.ConvertToHTMLStream(ctx, &buf, data); err != nil {
+			b.Fatalf("ConvertToHTMLStream failed: %v", err)
+		}
+	}
+}
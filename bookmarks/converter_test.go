@@ -469,6 +469,43 @@ func TestHTMLConverter_ConvertToHTML(t *testing.T) {
 	}
 }
 
+func TestHTMLConverter_WithFavicons(t *testing.T) {
+	converter := NewHTMLConverter(WithFavicons(&stubFaviconFetcher{icon: []byte("fake-png-bytes")}))
+
+	data := &BookmarkData{
+		Title:    "Bookmarks Menu",
+		Children: []BookmarkData{{Title: "GitHub", URI: "https://github.com"}},
+	}
+
+	var buf bytes.Buffer
+	writer := NewHTMLWriter(&buf)
+	if err := converter.ConvertToHTML(writer, data, 0); err != nil {
+		t.Fatalf("ConvertToHTML failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `ICON="data:image/png;base64,`) {
+		t.Errorf("expected embedded ICON attribute, got:\n%s", buf.String())
+	}
+}
+
+func TestHTMLConverter_NoFaviconFetcherConfigured(t *testing.T) {
+	converter := NewHTMLConverter()
+
+	data := &BookmarkData{
+		Title:    "Bookmarks Menu",
+		Children: []BookmarkData{{Title: "GitHub", URI: "https://github.com"}},
+	}
+
+	var buf bytes.Buffer
+	if err := converter.ConvertToHTML(NewHTMLWriter(&buf), data, 0); err != nil {
+		t.Fatalf("ConvertToHTML failed: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "ICON=") {
+		t.Error("expected no ICON attribute without WithFavicons")
+	}
+}
+
 func TestConvertBookmarksToHTML(t *testing.T) {
 	// Test the convenience function
 	testData := &BookmarkData{
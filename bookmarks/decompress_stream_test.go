@@ -0,0 +1,82 @@
+package bookmarks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestBookmarkLoader_DecompressJSONLZ4_NoTruncationAboveDefaultBufferSize
+// proves that decompression sizes its destination buffer from the mozLz4
+// header instead of the fixed DefaultBufferSize, which used to silently
+// truncate backups larger than 10MB.
+func TestBookmarkLoader_DecompressJSONLZ4_NoTruncationAboveDefaultBufferSize(t *testing.T) {
+	const wantChildren = 200000
+
+	children := make([]BookmarkData, wantChildren)
+	for i := range children {
+		children[i] = BookmarkData{
+			Title: fmt.Sprintf("Bookmark %d", i),
+			URI:   fmt.Sprintf("https://example.com/%d", i),
+		}
+	}
+	original := BookmarkData{Title: "Bookmarks Menu", Children: children}
+
+	path := filepath.Join(t.TempDir(), "large.jsonlz4")
+	if err := WriteJSONLZ4File(path, &original); err != nil {
+		t.Fatalf("WriteJSONLZ4File failed: %v", err)
+	}
+
+	loader := NewBookmarkLoader()
+	result, err := loader.DecompressJSONLZ4(path)
+	if err != nil {
+		t.Fatalf("DecompressJSONLZ4 failed: %v", err)
+	}
+
+	if len(result.Children) != wantChildren {
+		t.Fatalf("Children length = %d, want %d (backup was truncated)", len(result.Children), wantChildren)
+	}
+	last := result.Children[wantChildren-1]
+	if last.URI != fmt.Sprintf("https://example.com/%d", wantChildren-1) {
+		t.Errorf("last child URI = %q, did not survive decompression intact", last.URI)
+	}
+}
+
+// TestBookmarkLoader_DecompressJSONLZ4Reader_ZeroSizeHeaderFallsBack checks
+// that a mozLz4 header with a zero uncompressed-size field (as legacy
+// backups may have) still decompresses via the growable-buffer fallback.
+func TestBookmarkLoader_DecompressJSONLZ4Reader_ZeroSizeHeaderFallsBack(t *testing.T) {
+	data := BookmarkData{Title: "Bookmarks Menu", Children: []BookmarkData{{Title: "Test", URI: "https://example.com"}}}
+
+	path := filepath.Join(t.TempDir(), "zero-size.jsonlz4")
+	if err := WriteJSONLZ4File(path, &data); err != nil {
+		t.Fatalf("WriteJSONLZ4File failed: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+	// Zero out the uncompressed-size field to simulate a header that
+	// doesn't carry a usable size.
+	for i := 8; i < FirefoxLZ4HeaderSize; i++ {
+		raw[i] = 0
+	}
+
+	loader := NewBookmarkLoader()
+	reader, err := loader.DecompressJSONLZ4Reader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("DecompressJSONLZ4Reader failed: %v", err)
+	}
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read decompressed reader: %v", err)
+	}
+	if len(decoded) == 0 {
+		t.Error("expected non-empty decompressed JSON")
+	}
+}
@@ -0,0 +1,390 @@
+package bookmarks
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// BookmarkContentFetcher fetches a bookmark's full content (e.g. a
+// readability-extracted article body) for inclusion in its EPUB chapter
+// entry, beyond the title/URI/description every bookmark gets by default.
+type BookmarkContentFetcher interface {
+	FetchContent(ctx context.Context, pageURL string) (string, error)
+}
+
+// EPUBOptions configures ConvertBookmarksToEPUB.
+type EPUBOptions struct {
+	// Title is the ebook's title. Defaults to "Bookmarks" if empty.
+	Title string
+	// Author is the ebook's author metadata. Defaults to "Firefox
+	// Bookmarks" if empty.
+	Author string
+	// CoverImage is an optional cover image's raw bytes.
+	CoverImage []byte
+	// CoverImageType is CoverImage's MIME type, e.g. "image/jpeg" or
+	// "image/png". Required when CoverImage is set.
+	CoverImageType string
+	// ContentFetcher, if set, is used to fetch each bookmark's full
+	// content for its chapter entry. Fetch errors are ignored and the
+	// bookmark falls back to its title/URI/description.
+	ContentFetcher BookmarkContentFetcher
+}
+
+// EPUBExporter exports bookmarks as an EPUB 3 ebook, with one chapter per
+// top-level folder, analogous to how shiori's ebook feature turns saved
+// bookmarks into a readable ebook.
+type EPUBExporter struct {
+	Options EPUBOptions
+}
+
+// NewEPUBExporter creates a new EPUBExporter.
+func NewEPUBExporter(opts EPUBOptions) *EPUBExporter {
+	return &EPUBExporter{Options: opts}
+}
+
+// Export writes data to w as an EPUB 3 archive.
+func (e *EPUBExporter) Export(w io.Writer, data *BookmarkData) error {
+	return ConvertBookmarksToEPUB(w, data, e.Options)
+}
+
+// epubChapter is one top-level folder (or the synthesized "Bookmarks"
+// chapter for loose top-level bookmarks), rendered as its own XHTML
+// document.
+type epubChapter struct {
+	id        string
+	file      string
+	title     string
+	bookmarks []BookmarkData
+}
+
+// ConvertBookmarksToEPUB walks data and writes w as a valid EPUB 3 archive:
+// a stored (uncompressed) mimetype entry, META-INF/container.xml, an OPF
+// package document with manifest and spine, an EPUB3 nav document plus a
+// legacy NCX for reader compatibility, and one XHTML chapter per top-level
+// folder listing its bookmarks with descriptions drawn from annotations.
+func ConvertBookmarksToEPUB(w io.Writer, data *BookmarkData, opts EPUBOptions) error {
+	title := opts.Title
+	if title == "" {
+		title = "Bookmarks"
+	}
+	author := opts.Author
+	if author == "" {
+		author = "Firefox Bookmarks"
+	}
+	identifier := epubIdentifier(title, author)
+
+	chapters := epubChapters(data)
+	if len(chapters) == 0 {
+		chapters = []epubChapter{{id: "chapter-1", file: "chapter-1.xhtml", title: title}}
+	}
+
+	zw := zip.NewWriter(w)
+
+	if err := writeEPUBMimetype(zw); err != nil {
+		return err
+	}
+	if err := writeEPUBContainer(zw); err != nil {
+		return err
+	}
+
+	var coverManifestItem, coverMetaProperty string
+	if len(opts.CoverImage) > 0 {
+		coverFile, err := epubCoverFileName(opts.CoverImageType)
+		if err != nil {
+			return err
+		}
+		if err := writeEPUBFile(zw, "OEBPS/"+coverFile, opts.CoverImage); err != nil {
+			return err
+		}
+		coverManifestItem = fmt.Sprintf("    <item id=\"cover-image\" href=\"%s\" media-type=\"%s\" properties=\"cover-image\"/>\n", coverFile, opts.CoverImageType)
+		coverMetaProperty = "    <meta name=\"cover\" content=\"cover-image\"/>\n"
+	}
+
+	for _, chapter := range chapters {
+		if err := writeEPUBChapter(zw, chapter, opts.ContentFetcher); err != nil {
+			return err
+		}
+	}
+
+	if err := writeEPUBNav(zw, title, chapters); err != nil {
+		return err
+	}
+	if err := writeEPUBNCX(zw, identifier, title, chapters); err != nil {
+		return err
+	}
+	if err := writeEPUBPackage(zw, identifier, title, author, chapters, coverManifestItem, coverMetaProperty); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// epubChapters groups data's tree into one chapter per top-level folder,
+// plus a synthesized "Bookmarks" chapter for any bookmarks sitting directly
+// under the root, skipping separators.
+func epubChapters(data *BookmarkData) []epubChapter {
+	var chapters []epubChapter
+	var loose []BookmarkData
+
+	for i := range data.Children {
+		child := &data.Children[i]
+		switch {
+		case child.TypeCode == BookmarkSeparatorType:
+			continue
+		case child.Children != nil:
+			chapters = append(chapters, epubChapter{
+				id:        fmt.Sprintf("chapter-%d", len(chapters)+1),
+				file:      fmt.Sprintf("chapter-%d.xhtml", len(chapters)+1),
+				title:     child.Title,
+				bookmarks: collectLeafBookmarks(child),
+			})
+		case child.URI != "":
+			loose = append(loose, *child)
+		}
+	}
+
+	if len(loose) > 0 {
+		chapters = append(chapters, epubChapter{
+			id:        fmt.Sprintf("chapter-%d", len(chapters)+1),
+			file:      fmt.Sprintf("chapter-%d.xhtml", len(chapters)+1),
+			title:     "Bookmarks",
+			bookmarks: loose,
+		})
+	}
+
+	return chapters
+}
+
+// collectLeafBookmarks recursively flattens folder's descendants into a
+// single list of bookmarks, skipping nested folders and separators.
+func collectLeafBookmarks(folder *BookmarkData) []BookmarkData {
+	var out []BookmarkData
+	for i := range folder.Children {
+		child := &folder.Children[i]
+		switch {
+		case child.TypeCode == BookmarkSeparatorType:
+			continue
+		case child.Children != nil:
+			out = append(out, collectLeafBookmarks(child)...)
+		case child.URI != "":
+			out = append(out, *child)
+		}
+	}
+	return out
+}
+
+// bookmarkDisplayTitle returns bm's title, falling back to its URI if the
+// title is empty.
+func bookmarkDisplayTitle(bm *BookmarkData) string {
+	if bm.Title != "" {
+		return bm.Title
+	}
+	return bm.URI
+}
+
+// bookmarkDescription returns bm's description annotation, or "" if it has
+// none.
+func bookmarkDescription(bm *BookmarkData) string {
+	for _, anno := range bm.Annotations {
+		if anno.Name == netscapeDescriptionAnno {
+			return anno.Value
+		}
+	}
+	return ""
+}
+
+// writeEPUBFile writes a single Deflate-compressed entry to zw.
+func writeEPUBFile(zw *zip.Writer, name string, content []byte) error {
+	entry, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("error creating EPUB entry %q: %w", name, err)
+	}
+	if _, err := entry.Write(content); err != nil {
+		return fmt.Errorf("error writing EPUB entry %q: %w", name, err)
+	}
+	return nil
+}
+
+// writeEPUBMimetype writes the EPUB "mimetype" entry, which the spec
+// requires to be the archive's first entry and stored uncompressed.
+func writeEPUBMimetype(zw *zip.Writer) error {
+	entry, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("error creating EPUB mimetype entry: %w", err)
+	}
+	_, err = entry.Write([]byte("application/epub+zip"))
+	return err
+}
+
+const epubContainerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/package.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+// writeEPUBContainer writes META-INF/container.xml, pointing readers at the
+// OPF package document.
+func writeEPUBContainer(zw *zip.Writer) error {
+	return writeEPUBFile(zw, "META-INF/container.xml", []byte(epubContainerXML))
+}
+
+const epubChapterTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head>
+  <title>%s</title>
+  <meta charset="utf-8"/>
+</head>
+<body>
+  <h1>%s</h1>
+%s</body>
+</html>
+`
+
+// writeEPUBChapter renders chapter's bookmarks as an XHTML document and
+// writes it to the archive. When fetcher is set, each bookmark's fetched
+// content is included below its title and description.
+func writeEPUBChapter(zw *zip.Writer, chapter epubChapter, fetcher BookmarkContentFetcher) error {
+	var body strings.Builder
+	for _, bm := range chapter.bookmarks {
+		fmt.Fprintf(&body, "  <section>\n    <h2><a href=\"%s\">%s</a></h2>\n", html.EscapeString(bm.URI), html.EscapeString(bookmarkDisplayTitle(&bm)))
+
+		if description := bookmarkDescription(&bm); description != "" {
+			fmt.Fprintf(&body, "    <p>%s</p>\n", html.EscapeString(description))
+		}
+
+		if fetcher != nil {
+			if content, err := fetcher.FetchContent(context.Background(), bm.URI); err == nil && content != "" {
+				fmt.Fprintf(&body, "    <div>%s</div>\n", html.EscapeString(content))
+			}
+		}
+
+		body.WriteString("  </section>\n")
+	}
+
+	escapedTitle := html.EscapeString(chapter.title)
+	xhtml := fmt.Sprintf(epubChapterTemplate, escapedTitle, escapedTitle, body.String())
+	return writeEPUBFile(zw, "OEBPS/"+chapter.file, []byte(xhtml))
+}
+
+const epubNavTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head>
+  <title>%[1]s</title>
+  <meta charset="utf-8"/>
+</head>
+<body>
+  <nav epub:type="toc" id="toc">
+    <h1>%[1]s</h1>
+    <ol>
+%s    </ol>
+  </nav>
+</body>
+</html>
+`
+
+// writeEPUBNav writes the EPUB3 navigation document listing every chapter.
+func writeEPUBNav(zw *zip.Writer, title string, chapters []epubChapter) error {
+	var items strings.Builder
+	for _, chapter := range chapters {
+		fmt.Fprintf(&items, "      <li><a href=\"%s\">%s</a></li>\n", chapter.file, html.EscapeString(chapter.title))
+	}
+
+	nav := fmt.Sprintf(epubNavTemplate, html.EscapeString(title), items.String())
+	return writeEPUBFile(zw, "OEBPS/nav.xhtml", []byte(nav))
+}
+
+const epubNCXTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head>
+    <meta name="dtb:uid" content="%s"/>
+  </head>
+  <docTitle><text>%s</text></docTitle>
+  <navMap>
+%s  </navMap>
+</ncx>
+`
+
+// writeEPUBNCX writes a legacy EPUB2 NCX document, kept alongside nav.xhtml
+// for readers that don't understand EPUB3 navigation.
+func writeEPUBNCX(zw *zip.Writer, identifier, title string, chapters []epubChapter) error {
+	var navPoints strings.Builder
+	for i, chapter := range chapters {
+		fmt.Fprintf(&navPoints, "    <navPoint id=\"navpoint-%d\" playOrder=\"%d\">\n      <navLabel><text>%s</text></navLabel>\n      <content src=\"%s\"/>\n    </navPoint>\n",
+			i+1, i+1, html.EscapeString(chapter.title), chapter.file)
+	}
+
+	ncx := fmt.Sprintf(epubNCXTemplate, identifier, html.EscapeString(title), navPoints.String())
+	return writeEPUBFile(zw, "OEBPS/toc.ncx", []byte(ncx))
+}
+
+const epubPackageTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">urn:uuid:%s</dc:identifier>
+    <dc:title>%s</dc:title>
+    <dc:creator>%s</dc:creator>
+    <dc:language>en</dc:language>
+    <meta property="dcterms:modified">%s</meta>
+%s  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+%s  </manifest>
+  <spine toc="ncx">
+%s  </spine>
+</package>
+`
+
+// writeEPUBPackage writes the OPF package document tying every other part
+// of the archive together via its manifest and spine.
+func writeEPUBPackage(zw *zip.Writer, identifier, title, author string, chapters []epubChapter, coverManifestItem, coverMetaProperty string) error {
+	var manifestItems, spineItems strings.Builder
+	for _, chapter := range chapters {
+		fmt.Fprintf(&manifestItems, "    <item id=\"%s\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", chapter.id, chapter.file)
+		fmt.Fprintf(&spineItems, "    <itemref idref=\"%s\"/>\n", chapter.id)
+	}
+	manifestItems.WriteString(coverManifestItem)
+
+	opf := fmt.Sprintf(epubPackageTemplate,
+		identifier,
+		html.EscapeString(title),
+		html.EscapeString(author),
+		time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		coverMetaProperty,
+		manifestItems.String(),
+		spineItems.String(),
+	)
+	return writeEPUBFile(zw, "OEBPS/package.opf", []byte(opf))
+}
+
+// epubIdentifier derives a stable urn:uuid-shaped identifier from title and
+// author, so re-exporting the same bookmarks produces the same identifier.
+func epubIdentifier(title, author string) string {
+	sum := sha1.Sum([]byte(title + "|" + author))
+	hexSum := hex.EncodeToString(sum[:16])
+	return fmt.Sprintf("%s-%s-%s-%s-%s", hexSum[0:8], hexSum[8:12], hexSum[12:16], hexSum[16:20], hexSum[20:32])
+}
+
+// epubCoverFileName returns the archive file name to use for a cover image
+// of the given MIME type.
+func epubCoverFileName(mimeType string) (string, error) {
+	switch mimeType {
+	case "image/png":
+		return "cover.png", nil
+	case "image/jpeg", "image/jpg":
+		return "cover.jpg", nil
+	case "image/gif":
+		return "cover.gif", nil
+	default:
+		return "", fmt.Errorf("unsupported cover image type %q", mimeType)
+	}
+}
@@ -0,0 +1,185 @@
+package bookmarks
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func sampleEPUBData() BookmarkData {
+	return BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title: "Development",
+				Children: []BookmarkData{
+					{
+						Title: "GitHub",
+						URI:   "https://github.com",
+						Annotations: []Annotation{
+							{Name: netscapeDescriptionAnno, Value: "Where the code lives"},
+						},
+					},
+				},
+			},
+			{Title: "Example", URI: "https://example.com"},
+		},
+	}
+}
+
+// readEPUBEntries unzips buf's content into a map of file name to content,
+// for assertions below.
+func readEPUBEntries(t *testing.T, buf []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		t.Fatalf("failed to open EPUB as a zip archive: %v", err)
+	}
+
+	entries := make(map[string]string)
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open entry %q: %v", file.Name, err)
+		}
+		var content bytes.Buffer
+		if _, err := content.ReadFrom(rc); err != nil {
+			t.Fatalf("failed to read entry %q: %v", file.Name, err)
+		}
+		rc.Close()
+		entries[file.Name] = content.String()
+	}
+	return entries
+}
+
+func TestConvertBookmarksToEPUB(t *testing.T) {
+	data := sampleEPUBData()
+
+	var buf bytes.Buffer
+	if err := ConvertBookmarksToEPUB(&buf, &data, EPUBOptions{Title: "My Bookmarks", Author: "Jane Doe"}); err != nil {
+		t.Fatalf("ConvertBookmarksToEPUB failed: %v", err)
+	}
+
+	entries := readEPUBEntries(t, buf.Bytes())
+
+	if mimetype, ok := entries["mimetype"]; !ok || mimetype != "application/epub+zip" {
+		t.Errorf("mimetype entry = %q, ok=%v, want \"application/epub+zip\"", mimetype, ok)
+	}
+	for _, want := range []string{"META-INF/container.xml", "OEBPS/package.opf", "OEBPS/nav.xhtml", "OEBPS/toc.ncx", "OEBPS/chapter-1.xhtml", "OEBPS/chapter-2.xhtml"} {
+		if _, ok := entries[want]; !ok {
+			t.Errorf("missing expected EPUB entry %q", want)
+		}
+	}
+
+	if !strings.Contains(entries["OEBPS/package.opf"], "<dc:title>My Bookmarks</dc:title>") {
+		t.Errorf("package.opf missing title, got: %q", entries["OEBPS/package.opf"])
+	}
+	if !strings.Contains(entries["OEBPS/package.opf"], "<dc:creator>Jane Doe</dc:creator>") {
+		t.Errorf("package.opf missing author, got: %q", entries["OEBPS/package.opf"])
+	}
+
+	chapter1 := entries["OEBPS/chapter-1.xhtml"]
+	if !strings.Contains(chapter1, "<h1>Development</h1>") {
+		t.Errorf("chapter-1 missing folder title, got: %q", chapter1)
+	}
+	if !strings.Contains(chapter1, `href="https://github.com"`) {
+		t.Errorf("chapter-1 missing bookmark link, got: %q", chapter1)
+	}
+	if !strings.Contains(chapter1, "Where the code lives") {
+		t.Errorf("chapter-1 missing description, got: %q", chapter1)
+	}
+
+	chapter2 := entries["OEBPS/chapter-2.xhtml"]
+	if !strings.Contains(chapter2, "<h1>Bookmarks</h1>") {
+		t.Errorf("chapter-2 missing synthesized loose-bookmarks title, got: %q", chapter2)
+	}
+	if !strings.Contains(chapter2, `href="https://example.com"`) {
+		t.Errorf("chapter-2 missing bookmark link, got: %q", chapter2)
+	}
+
+	if !strings.Contains(entries["OEBPS/nav.xhtml"], `href="chapter-1.xhtml"`) {
+		t.Errorf("nav.xhtml missing chapter-1 link, got: %q", entries["OEBPS/nav.xhtml"])
+	}
+}
+
+func TestConvertBookmarksToEPUB_MimetypeIsStoredUncompressed(t *testing.T) {
+	data := BookmarkData{Title: "Bookmarks Menu"}
+
+	var buf bytes.Buffer
+	if err := ConvertBookmarksToEPUB(&buf, &data, EPUBOptions{}); err != nil {
+		t.Fatalf("ConvertBookmarksToEPUB failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to open EPUB as a zip archive: %v", err)
+	}
+	if len(zr.File) == 0 || zr.File[0].Name != "mimetype" {
+		t.Fatalf("expected mimetype as the first archive entry, got: %+v", zr.File)
+	}
+	if zr.File[0].Method != zip.Store {
+		t.Errorf("mimetype entry Method = %v, want zip.Store (uncompressed)", zr.File[0].Method)
+	}
+}
+
+type stubContentFetcher struct {
+	content string
+	err     error
+}
+
+func (f *stubContentFetcher) FetchContent(ctx context.Context, pageURL string) (string, error) {
+	return f.content, f.err
+}
+
+func TestConvertBookmarksToEPUB_WithContentFetcher(t *testing.T) {
+	data := BookmarkData{
+		Title:    "Bookmarks Menu",
+		Children: []BookmarkData{{Title: "Example", URI: "https://example.com"}},
+	}
+
+	var buf bytes.Buffer
+	opts := EPUBOptions{ContentFetcher: &stubContentFetcher{content: "Full article text"}}
+	if err := ConvertBookmarksToEPUB(&buf, &data, opts); err != nil {
+		t.Fatalf("ConvertBookmarksToEPUB failed: %v", err)
+	}
+
+	entries := readEPUBEntries(t, buf.Bytes())
+	if !strings.Contains(entries["OEBPS/chapter-1.xhtml"], "Full article text") {
+		t.Errorf("expected fetched content in chapter, got: %q", entries["OEBPS/chapter-1.xhtml"])
+	}
+}
+
+func TestConvertBookmarksToEPUB_WithCoverImage(t *testing.T) {
+	data := BookmarkData{Title: "Bookmarks Menu"}
+	cover := []byte{0x89, 0x50, 0x4E, 0x47}
+
+	var buf bytes.Buffer
+	opts := EPUBOptions{CoverImage: cover, CoverImageType: "image/png"}
+	if err := ConvertBookmarksToEPUB(&buf, &data, opts); err != nil {
+		t.Fatalf("ConvertBookmarksToEPUB failed: %v", err)
+	}
+
+	entries := readEPUBEntries(t, buf.Bytes())
+	if got := entries["OEBPS/cover.png"]; got != string(cover) {
+		t.Errorf("cover.png content = %v, want %v", []byte(got), cover)
+	}
+	if !strings.Contains(entries["OEBPS/package.opf"], `properties="cover-image"`) {
+		t.Errorf("package.opf missing cover-image manifest item, got: %q", entries["OEBPS/package.opf"])
+	}
+}
+
+func TestEPUBExporter_Export(t *testing.T) {
+	data := sampleEPUBData()
+
+	var buf bytes.Buffer
+	if err := NewEPUBExporter(EPUBOptions{Title: "My Bookmarks"}).Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	entries := readEPUBEntries(t, buf.Bytes())
+	if _, ok := entries["OEBPS/package.opf"]; !ok {
+		t.Error("expected a package.opf entry in the exported archive")
+	}
+}
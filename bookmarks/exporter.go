@@ -0,0 +1,211 @@
+package bookmarks
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Exporter converts a BookmarkData tree into a specific output format.
+type Exporter interface {
+	// Export writes data to w in the exporter's format.
+	Export(w io.Writer, data *BookmarkData) error
+}
+
+// firefoxTimeToRFC3339 converts a Firefox PRTime (microseconds since the
+// Unix epoch) into an RFC3339 timestamp, or "" if the timestamp is unset.
+func firefoxTimeToRFC3339(timestamp int64) string {
+	if timestamp == 0 {
+		return ""
+	}
+	return time.UnixMicro(timestamp).UTC().Format(time.RFC3339)
+}
+
+// HTMLExporter exports bookmarks as a Netscape Bookmark File, matching the
+// format Firefox itself reads and writes.
+type HTMLExporter struct {
+	opts []HTMLConverterOption
+}
+
+// NewHTMLExporter creates a new HTMLExporter. opts are passed through to
+// the underlying HTMLConverter, e.g. WithFavicons.
+func NewHTMLExporter(opts ...HTMLConverterOption) *HTMLExporter {
+	return &HTMLExporter{opts: opts}
+}
+
+// Export writes data to w as Netscape Bookmark File HTML.
+func (e *HTMLExporter) Export(w io.Writer, data *BookmarkData) error {
+	converter := NewHTMLConverter(e.opts...)
+	return converter.ConvertToHTML(NewHTMLWriter(w), data, 0)
+}
+
+// jsonExportNode is the normalized schema written by JSONExporter, decoupled
+// from BookmarkData's Firefox-specific field names and timestamp encoding.
+type jsonExportNode struct {
+	ID        int              `json:"id"`
+	Name      string           `json:"name"`
+	URL       string           `json:"url,omitempty"`
+	DateAdded string           `json:"dateAdded,omitempty"`
+	Type      string           `json:"type"`
+	Children  []jsonExportNode `json:"children,omitempty"`
+}
+
+// JSONExporter exports bookmarks as pretty-printed JSON using a normalized
+// schema (id, name, url, dateAdded, type, children) rather than Firefox's
+// internal field names.
+type JSONExporter struct{}
+
+// NewJSONExporter creates a new JSONExporter.
+func NewJSONExporter() *JSONExporter {
+	return &JSONExporter{}
+}
+
+// toJSONExportNode converts data and its children into the normalized export
+// schema, assigning sequential ids in tree order.
+func toJSONExportNode(data *BookmarkData, nextID *int) jsonExportNode {
+	id := *nextID
+	*nextID++
+
+	nodeType := "bookmark"
+	if data.Children != nil {
+		nodeType = "folder"
+	}
+
+	node := jsonExportNode{
+		ID:        id,
+		Name:      data.Title,
+		URL:       data.URI,
+		DateAdded: firefoxTimeToRFC3339(data.DateAdded),
+		Type:      nodeType,
+	}
+
+	for _, child := range data.Children {
+		if child.TypeCode == BookmarkSeparatorType {
+			continue
+		}
+		node.Children = append(node.Children, toJSONExportNode(&child, nextID))
+	}
+
+	return node
+}
+
+// Export writes data to w as normalized, pretty-printed JSON.
+func (e *JSONExporter) Export(w io.Writer, data *BookmarkData) error {
+	nextID := 1
+	root := toJSONExportNode(data, &nextID)
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return fmt.Errorf("error encoding JSON: %w", err)
+	}
+	return nil
+}
+
+// csvHeader lists the columns written by CSVExporter, in order.
+var csvHeader = []string{"folder_path", "title", "url", "description", "date_added", "last_modified"}
+
+// CSVExporter exports bookmarks as a flattened CSV with one row per
+// bookmark, prefixed with a UTF-8 BOM for compatibility with Excel.
+type CSVExporter struct{}
+
+// NewCSVExporter creates a new CSVExporter.
+func NewCSVExporter() *CSVExporter {
+	return &CSVExporter{}
+}
+
+// Export writes data to w as UTF-8 BOM-prefixed CSV.
+func (e *CSVExporter) Export(w io.Writer, data *BookmarkData) error {
+	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return fmt.Errorf("error writing BOM: %w", err)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("error writing CSV header: %w", err)
+	}
+
+	if err := writeCSVRows(writer, data, ""); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error flushing CSV: %w", err)
+	}
+	return nil
+}
+
+// writeCSVRows recursively flattens data into CSV rows, tracking the
+// slash-separated folder path of the current node's ancestors.
+func writeCSVRows(writer *csv.Writer, data *BookmarkData, folderPath string) error {
+	if data.Children != nil {
+		childPath := folderPath
+		if data.Title != "" {
+			if childPath != "" {
+				childPath += "/"
+			}
+			childPath += data.Title
+		}
+
+		for _, child := range data.Children {
+			if child.TypeCode == BookmarkSeparatorType {
+				continue
+			}
+			if err := writeCSVRows(writer, &child, childPath); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if data.URI == "" {
+		return nil
+	}
+
+	description := ""
+	for _, anno := range data.Annotations {
+		if anno.Name == "bookmarkProperties/description" {
+			description = anno.Value
+		}
+	}
+
+	row := []string{
+		folderPath,
+		data.Title,
+		data.URI,
+		description,
+		firefoxTimeToRFC3339(data.DateAdded),
+		firefoxTimeToRFC3339(data.LastModified),
+	}
+
+	if err := writer.Write(row); err != nil {
+		return fmt.Errorf("error writing CSV row: %w", err)
+	}
+	return nil
+}
+
+// ExporterForFormat returns the Exporter registered for the given format
+// name ("html", "json", "csv", "md"/"markdown", "opml" or "epub"), or an
+// error if the format is unknown.
+func ExporterForFormat(format string) (Exporter, error) {
+	switch format {
+	case "html":
+		return NewHTMLExporter(), nil
+	case "json":
+		return NewJSONExporter(), nil
+	case "csv":
+		return NewCSVExporter(), nil
+	case "md", "markdown":
+		return NewMarkdownExporter(), nil
+	case "opml":
+		return NewOPMLExporter(), nil
+	case "epub":
+		return NewEPUBExporter(EPUBOptions{}), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
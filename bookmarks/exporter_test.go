@@ -0,0 +1,158 @@
+package bookmarks
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHTMLExporter_Export(t *testing.T) {
+	data := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{Title: "GitHub", URI: "https://github.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLExporter().Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "<!DOCTYPE NETSCAPE-Bookmark-file-1>") {
+		t.Error("Expected Netscape bookmark file header in output")
+	}
+}
+
+func TestJSONExporter_Export(t *testing.T) {
+	data := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title:     "GitHub",
+				URI:       "https://github.com",
+				DateAdded: 1639123456789000,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJSONExporter().Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	var root jsonExportNode
+	if err := json.Unmarshal(buf.Bytes(), &root); err != nil {
+		t.Fatalf("Failed to unmarshal exported JSON: %v", err)
+	}
+
+	if root.Type != "folder" {
+		t.Errorf("Type = %v, want %v", root.Type, "folder")
+	}
+	if len(root.Children) != 1 {
+		t.Fatalf("Children length = %v, want %v", len(root.Children), 1)
+	}
+
+	child := root.Children[0]
+	if child.Type != "bookmark" {
+		t.Errorf("child Type = %v, want %v", child.Type, "bookmark")
+	}
+	if child.URL != "https://github.com" {
+		t.Errorf("child URL = %v, want %v", child.URL, "https://github.com")
+	}
+	if child.DateAdded != "2021-12-10T08:04:16Z" {
+		t.Errorf("child DateAdded = %v, want %v", child.DateAdded, "2021-12-10T08:04:16Z")
+	}
+}
+
+func TestJSONExporter_Export_NoEscapeHTML(t *testing.T) {
+	data := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{Title: "Tom & Jerry", URI: "https://example.com?a=1&b=2"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJSONExporter().Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "\\u0026") {
+		t.Error("Expected raw ampersand, JSON escaping should be disabled")
+	}
+}
+
+func TestCSVExporter_Export(t *testing.T) {
+	data := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title: "Development",
+				Children: []BookmarkData{
+					{
+						Title: "GitHub",
+						URI:   "https://github.com",
+						Annotations: []Annotation{
+							{Name: "bookmarkProperties/description", Value: "Code hosting"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewCSVExporter().Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "\xef\xbb\xbf") {
+		t.Error("Expected UTF-8 BOM prefix")
+	}
+
+	if !strings.Contains(out, "folder_path,title,url,description,date_added,last_modified") {
+		t.Error("Expected CSV header row")
+	}
+
+	if !strings.Contains(out, "Bookmarks Menu/Development,GitHub,https://github.com,Code hosting") {
+		t.Errorf("Expected flattened bookmark row, got: %q", out)
+	}
+}
+
+func TestExporterForFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"html", false},
+		{"json", false},
+		{"csv", false},
+		{"md", false},
+		{"markdown", false},
+		{"opml", false},
+		{"epub", false},
+		{"xml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			exporter, err := ExporterForFormat(tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ExporterForFormat(%q) expected error, got nil", tt.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExporterForFormat(%q) failed: %v", tt.format, err)
+			}
+			if exporter == nil {
+				t.Errorf("ExporterForFormat(%q) returned nil exporter", tt.format)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package bookmarks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// faviconSize is the width and height (in pixels) favicons are downsized to
+// before being embedded as ICON= attributes.
+const faviconSize = 16
+
+// FaviconFetcher resolves the favicon for a bookmark's page URL, returning
+// it as 16x16 PNG-encoded image bytes, or (nil, nil) if none could be
+// found. Implementations are used with HTMLConverter's WithFavicons option.
+type FaviconFetcher interface {
+	Favicon(ctx context.Context, pageURL string) ([]byte, error)
+}
+
+// defaultFaviconConcurrency bounds the number of simultaneous favicon
+// fetches an HTTPFaviconFetcher will issue.
+const defaultFaviconConcurrency = 4
+
+// HTTPFaviconFetcher is the default FaviconFetcher: it issues a bounded-
+// concurrency HTTP GET for "<scheme>://<host>/favicon.ico", following
+// redirects, validates the response is actually an image, and downsizes it
+// to a 16x16 PNG.
+type HTTPFaviconFetcher struct {
+	Client    *http.Client
+	UserAgent string
+	Timeout   time.Duration
+
+	sem chan struct{}
+}
+
+// NewHTTPFaviconFetcher creates an HTTPFaviconFetcher that issues at most
+// maxConcurrent simultaneous fetches (defaultFaviconConcurrency if
+// maxConcurrent < 1).
+func NewHTTPFaviconFetcher(maxConcurrent int) *HTTPFaviconFetcher {
+	if maxConcurrent < 1 {
+		maxConcurrent = defaultFaviconConcurrency
+	}
+	return &HTTPFaviconFetcher{
+		Client:    &http.Client{Timeout: 10 * time.Second},
+		UserAgent: "firefox-bookmarks/1.0 (+https://github.com/panz3r/firefox-bookmarks)",
+		Timeout:   10 * time.Second,
+		sem:       make(chan struct{}, maxConcurrent),
+	}
+}
+
+// Favicon fetches and downsizes the favicon for pageURL. Network and
+// decoding failures are treated as "no favicon" rather than errors, since a
+// missing favicon shouldn't abort an export.
+func (f *HTTPFaviconFetcher) Favicon(ctx context.Context, pageURL string) ([]byte, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, nil
+	}
+
+	f.sem <- struct{}{}
+	defer func() { <-f.sem }()
+
+	ctx, cancel := context.WithTimeout(ctx, f.Timeout)
+	defer cancel()
+
+	faviconURL := fmt.Sprintf("%s://%s/favicon.ico", parsed.Scheme, parsed.Host)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, faviconURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.UserAgent)
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, nil
+	}
+
+	return resizeToFaviconPNG(body)
+}
+
+// resizeToFaviconPNG decodes an arbitrary image and downsizes it to a
+// faviconSize x faviconSize PNG using nearest-neighbor sampling. It returns
+// (nil, nil), rather than an error, if data isn't a decodable image, since
+// a malformed favicon shouldn't abort an export.
+func resizeToFaviconPNG(data []byte) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil
+	}
+
+	bounds := img.Bounds()
+	resized := image.NewRGBA(image.Rect(0, 0, faviconSize, faviconSize))
+	for y := 0; y < faviconSize; y++ {
+		srcY := bounds.Min.Y + y*bounds.Dy()/faviconSize
+		for x := 0; x < faviconSize; x++ {
+			srcX := bounds.Min.X + x*bounds.Dx()/faviconSize
+			resized.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, resized); err != nil {
+		return nil, fmt.Errorf("error encoding favicon PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DirCacheFaviconFetcher wraps another FaviconFetcher with an on-disk cache
+// keyed by the page URL's host, so repeated exports don't refetch the same
+// favicon.
+type DirCacheFaviconFetcher struct {
+	Dir    string
+	Source FaviconFetcher
+}
+
+// NewDirCacheFaviconFetcher creates a DirCacheFaviconFetcher caching
+// source's results under dir.
+func NewDirCacheFaviconFetcher(dir string, source FaviconFetcher) *DirCacheFaviconFetcher {
+	return &DirCacheFaviconFetcher{Dir: dir, Source: source}
+}
+
+// Favicon returns the cached favicon for pageURL's host if present,
+// otherwise fetches it from Source and writes it to the cache.
+func (f *DirCacheFaviconFetcher) Favicon(ctx context.Context, pageURL string) ([]byte, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || parsed.Host == "" {
+		return f.Source.Favicon(ctx, pageURL)
+	}
+
+	cachePath := filepath.Join(f.Dir, strings.ToLower(parsed.Host)+".png")
+	if cached, err := os.ReadFile(cachePath); err == nil {
+		return cached, nil
+	}
+
+	data, err := f.Source.Favicon(ctx, pageURL)
+	if err != nil || len(data) == 0 {
+		return data, err
+	}
+
+	if err := os.MkdirAll(f.Dir, 0o755); err == nil {
+		_ = os.WriteFile(cachePath, data, 0o644)
+	}
+	return data, nil
+}
+
+// PlacesIconFetcher resolves favicons from a Firefox profile's
+// places.sqlite moz_icons table instead of fetching over HTTP, so exports
+// can embed icons without network access.
+type PlacesIconFetcher struct {
+	db *sql.DB
+}
+
+// NewPlacesIconFetcher opens placesPath read-only.
+func NewPlacesIconFetcher(placesPath string) (*PlacesIconFetcher, error) {
+	db, err := sql.Open("sqlite", placesPath+"?mode=ro&immutable=1")
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", placesPath, err)
+	}
+	return &PlacesIconFetcher{db: db}, nil
+}
+
+// Favicon looks up the largest stored icon for pageURL's host in
+// moz_icons and downsizes it to a 16x16 PNG.
+func (f *PlacesIconFetcher) Favicon(ctx context.Context, pageURL string) ([]byte, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || parsed.Host == "" {
+		return nil, nil
+	}
+
+	row := f.db.QueryRowContext(ctx, `
+		SELECT i.data
+		FROM moz_icons i
+		JOIN moz_icons_to_pages ip ON ip.icon_id = i.id
+		JOIN moz_pages_w_icons p ON p.id = ip.page_id
+		WHERE p.page_url LIKE ?
+		ORDER BY i.width DESC
+		LIMIT 1`, "%"+parsed.Host+"%")
+
+	var data []byte
+	if err := row.Scan(&data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying moz_icons: %w", err)
+	}
+
+	return resizeToFaviconPNG(data)
+}
+
+// Close releases the underlying database handle.
+func (f *PlacesIconFetcher) Close() error {
+	return f.db.Close()
+}
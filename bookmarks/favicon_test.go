@@ -0,0 +1,97 @@
+package bookmarks
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// stubFaviconFetcher returns a fixed icon (or error) for every call, and
+// counts how many times Favicon was invoked.
+type stubFaviconFetcher struct {
+	icon  []byte
+	err   error
+	calls int
+}
+
+func (f *stubFaviconFetcher) Favicon(ctx context.Context, pageURL string) ([]byte, error) {
+	f.calls++
+	return f.icon, f.err
+}
+
+func samplePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode sample PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestResizeToFaviconPNG(t *testing.T) {
+	resized, err := resizeToFaviconPNG(samplePNG(t, 64, 64))
+	if err != nil {
+		t.Fatalf("resizeToFaviconPNG failed: %v", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(resized))
+	if err != nil {
+		t.Fatalf("failed to decode resized PNG: %v", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != faviconSize || bounds.Dy() != faviconSize {
+		t.Errorf("resized bounds = %v, want %dx%d", bounds, faviconSize, faviconSize)
+	}
+}
+
+func TestResizeToFaviconPNG_InvalidImageIsNotAnError(t *testing.T) {
+	resized, err := resizeToFaviconPNG([]byte("not an image"))
+	if err != nil {
+		t.Fatalf("expected no error for invalid image data, got: %v", err)
+	}
+	if resized != nil {
+		t.Errorf("expected nil result for invalid image data, got %d bytes", len(resized))
+	}
+}
+
+func TestDirCacheFaviconFetcher_CachesResults(t *testing.T) {
+	icon := samplePNG(t, 16, 16)
+	source := &stubFaviconFetcher{icon: icon}
+	fetcher := NewDirCacheFaviconFetcher(t.TempDir(), source)
+
+	first, err := fetcher.Favicon(context.Background(), "https://example.com/page")
+	if err != nil {
+		t.Fatalf("Favicon failed: %v", err)
+	}
+	if !bytes.Equal(first, icon) {
+		t.Error("expected first call to return the source's icon")
+	}
+	if source.calls != 1 {
+		t.Fatalf("source.calls = %d, want 1", source.calls)
+	}
+
+	second, err := fetcher.Favicon(context.Background(), "https://example.com/other-page")
+	if err != nil {
+		t.Fatalf("Favicon failed: %v", err)
+	}
+	if !bytes.Equal(second, icon) {
+		t.Error("expected cached call to return the same icon")
+	}
+	if source.calls != 1 {
+		t.Errorf("source.calls = %d, want 1 (second call should hit the cache)", source.calls)
+	}
+
+	if _, err := os.Stat(filepath.Join(fetcher.Dir, "example.com.png")); err != nil {
+		t.Errorf("expected a cache file for example.com, got: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package bookmarks
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// CompressJSONLZ4 marshals data back to Firefox's bookmark JSON layout,
+// LZ4-block-compresses it, and writes it to w prefixed with the 12-byte
+// mozLz4 header (an 8-byte signature followed by the little-endian
+// uncompressed size), mirroring the format DecompressJSONLZ4 reads.
+func CompressJSONLZ4(w io.Writer, data *BookmarkData) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshalling JSON: %w", err)
+	}
+
+	compressed := make([]byte, lz4.CompressBlockBound(len(jsonData)))
+	n, err := lz4.CompressBlock(jsonData, compressed, nil)
+	if err != nil {
+		return fmt.Errorf("LZ4 compression error: %w", err)
+	}
+
+	header := make([]byte, FirefoxLZ4HeaderSize)
+	copy(header, FirefoxLZ4Signature+"\x00\x00")
+	binary.LittleEndian.PutUint32(header[8:12], uint32(len(jsonData)))
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("error writing mozLz4 header: %w", err)
+	}
+	if _, err := w.Write(compressed[:n]); err != nil {
+		return fmt.Errorf("error writing compressed data: %w", err)
+	}
+
+	return nil
+}
+
+// WriteJSONLZ4File writes data to path in Firefox jsonlz4 format, suitable
+// for dropping into a profile's bookmarkbackups/ directory.
+func WriteJSONLZ4File(path string, data *BookmarkData) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	defer file.Close()
+
+	return CompressJSONLZ4(file, data)
+}
@@ -0,0 +1,46 @@
+package bookmarks
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompressJSONLZ4_RoundTrip(t *testing.T) {
+	original := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{Title: "GitHub", URI: "https://github.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := CompressJSONLZ4(&buf, &original); err != nil {
+		t.Fatalf("CompressJSONLZ4 failed: %v", err)
+	}
+
+	if buf.Len() < FirefoxLZ4HeaderSize {
+		t.Fatalf("compressed output too short: %d bytes", buf.Len())
+	}
+	if string(buf.Bytes()[:len(FirefoxLZ4Signature)]) != FirefoxLZ4Signature {
+		t.Errorf("expected mozLz4 signature, got %q", buf.Bytes()[:len(FirefoxLZ4Signature)])
+	}
+
+	loader := NewBookmarkLoader()
+	tempFile := filepath.Join(t.TempDir(), "roundtrip.jsonlz4")
+	if err := WriteJSONLZ4File(tempFile, &original); err != nil {
+		t.Fatalf("WriteJSONLZ4File failed: %v", err)
+	}
+
+	result, err := loader.DecompressJSONLZ4(tempFile)
+	if err != nil {
+		t.Fatalf("DecompressJSONLZ4 failed: %v", err)
+	}
+
+	if result.Title != original.Title {
+		t.Errorf("Title = %v, want %v", result.Title, original.Title)
+	}
+	if len(result.Children) != 1 || result.Children[0].URI != original.Children[0].URI {
+		t.Errorf("Children = %+v, want %+v", result.Children, original.Children)
+	}
+}
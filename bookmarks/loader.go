@@ -1,6 +1,8 @@
 package bookmarks
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +11,11 @@ import (
 	"github.com/pierrec/lz4/v4"
 )
 
+// maxGrowableBufferSize bounds how far DecompressJSONLZ4Reader will grow
+// its guessed output buffer when the mozLz4 header doesn't carry a usable
+// uncompressed size, to avoid an unbounded allocation loop on corrupt input.
+const maxGrowableBufferSize = 1 << 30 // 1GB
+
 // FileValidator handles file validation
 type FileValidator struct{}
 
@@ -55,6 +62,30 @@ func (fv *FileValidator) IsJSONFile(filename string) bool {
 	return decoder.Decode(&data) == nil
 }
 
+// ExternalLoader recognizes and loads bookmark export formats this package
+// can't parse natively (Chrome/Chromium/Edge, Safari, ...), so
+// LoadBookmarksFromFile can dispatch to them without this package depending
+// on bookmarks/sources, which itself depends on bookmarks. Register one with
+// RegisterExternalLoader; bookmarks/sources.LoaderRegistry satisfies this
+// interface.
+type ExternalLoader interface {
+	// CanLoad reports whether this loader recognizes path.
+	CanLoad(path string) bool
+	// Load reads and normalizes the bookmark tree at path.
+	Load(path string) (*BookmarkData, error)
+}
+
+// externalLoader is the ExternalLoader LoadBookmarksFromFile falls back to
+// for files that aren't Firefox's own jsonlz4/JSON formats, or nil if none
+// has been registered.
+var externalLoader ExternalLoader
+
+// RegisterExternalLoader installs loader as the fallback LoadBookmarksFromFile
+// uses for files it doesn't recognize natively.
+func RegisterExternalLoader(loader ExternalLoader) {
+	externalLoader = loader
+}
+
 // BookmarkLoader handles loading bookmark data from different file formats
 type BookmarkLoader struct {
 	validator *FileValidator
@@ -67,37 +98,70 @@ func NewBookmarkLoader() *BookmarkLoader {
 	}
 }
 
-// DecompressJSONLZ4 decompresses a Firefox jsonlz4 bookmark backup file and returns the JSON data
-func (bl *BookmarkLoader) DecompressJSONLZ4(filename string) (*BookmarkData, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, fmt.Errorf("file reading error: %w", err)
+// DecompressJSONLZ4Reader decompresses Firefox jsonlz4 data read from r and
+// returns a reader over the decompressed JSON, so callers can feed it
+// straight into json.NewDecoder instead of materializing the whole tree.
+// It sizes the destination buffer from the uncompressed-size field in the
+// mozLz4 header (bytes 8-11, little-endian) rather than a fixed ceiling, and
+// falls back to a growable buffer if that field is zero.
+func (bl *BookmarkLoader) DecompressJSONLZ4Reader(r io.Reader) (io.Reader, error) {
+	header := make([]byte, FirefoxLZ4HeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("error reading mozLz4 header: %w", err)
+	}
+	if string(header[:len(FirefoxLZ4Signature)]) != FirefoxLZ4Signature {
+		return nil, fmt.Errorf("invalid mozLz4 signature")
 	}
-	defer file.Close()
 
-	// Skip the Firefox LZ4 header
-	_, err = file.Seek(FirefoxLZ4HeaderSize, 0)
+	compressedData, err := io.ReadAll(r)
 	if err != nil {
-		return nil, fmt.Errorf("error seeking past header: %w", err)
+		return nil, fmt.Errorf("error reading compressed data: %w", err)
 	}
 
-	// Read the compressed data
-	compressedData, err := io.ReadAll(file)
+	uncompressedSize := binary.LittleEndian.Uint32(header[8:12])
+	if uncompressedSize > 0 {
+		decompressed := make([]byte, uncompressedSize)
+		n, err := lz4.UncompressBlock(compressedData, decompressed)
+		if err != nil {
+			return nil, fmt.Errorf("LZ4 decompression error: %w", err)
+		}
+		return bytes.NewReader(decompressed[:n]), nil
+	}
+
+	// The header didn't carry a usable size: grow a guessed buffer until
+	// UncompressBlock stops complaining that the destination is too small.
+	for size := DefaultBufferSize; ; size *= 2 {
+		if size > maxGrowableBufferSize {
+			size = maxGrowableBufferSize
+		}
+		decompressed := make([]byte, size)
+		n, err := lz4.UncompressBlock(compressedData, decompressed)
+		if err == nil {
+			return bytes.NewReader(decompressed[:n]), nil
+		}
+		if size == maxGrowableBufferSize {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("LZ4 decompression error: uncompressed data exceeds %d bytes", maxGrowableBufferSize)
+}
+
+// DecompressJSONLZ4 decompresses a Firefox jsonlz4 bookmark backup file and returns the JSON data
+func (bl *BookmarkLoader) DecompressJSONLZ4(filename string) (*BookmarkData, error) {
+	file, err := os.Open(filename)
 	if err != nil {
-		return nil, fmt.Errorf("error reading compressed data: %w", err)
+		return nil, fmt.Errorf("file reading error: %w", err)
 	}
+	defer file.Close()
 
-	// Decompress the data
-	decompressedData := make([]byte, DefaultBufferSize)
-	n, err := lz4.UncompressBlock(compressedData, decompressedData)
+	reader, err := bl.DecompressJSONLZ4Reader(file)
 	if err != nil {
-		return nil, fmt.Errorf("LZ4 decompression error: %w", err)
+		return nil, err
 	}
 
-	// Parse JSON
 	var bookmarkData BookmarkData
-	err = json.Unmarshal(decompressedData[:n], &bookmarkData)
-	if err != nil {
+	if err := json.NewDecoder(reader).Decode(&bookmarkData); err != nil {
 		return nil, fmt.Errorf("JSON parsing error: %w", err)
 	}
 
@@ -122,13 +186,18 @@ func (bl *BookmarkLoader) LoadJSONFile(filename string) (*BookmarkData, error) {
 	return &bookmarkData, nil
 }
 
-// LoadBookmarksFromFile loads bookmarks from a file, auto-detecting the format
+// LoadBookmarksFromFile loads bookmarks from a file, auto-detecting the
+// format: Firefox's jsonlz4 and plain JSON backups natively, and anything
+// else via the registered ExternalLoader (see RegisterExternalLoader).
 func (bl *BookmarkLoader) LoadBookmarksFromFile(filename string) (*BookmarkData, error) {
-	if bl.validator.IsValidJSONLZ4File(filename) {
+	switch {
+	case bl.validator.IsValidJSONLZ4File(filename):
 		return bl.DecompressJSONLZ4(filename)
-	} else if bl.validator.IsJSONFile(filename) {
+	case externalLoader != nil && externalLoader.CanLoad(filename):
+		return externalLoader.Load(filename)
+	case bl.validator.IsJSONFile(filename):
 		return bl.LoadJSONFile(filename)
-	} else {
+	default:
 		return nil, fmt.Errorf("file '%s' is not a valid Firefox bookmark backup file (.jsonlz4) or JSON file", filename)
 	}
 }
@@ -324,4 +324,41 @@ func TestBookmarkLoader_LoadBookmarksFromFile(t *testing.T) {
 			t.Error("Expected error for invalid file format")
 		}
 	})
+
+	// Test case 3: dispatches to a registered ExternalLoader for formats
+	// this package doesn't recognize natively.
+	t.Run("ExternalLoaderFallback", func(t *testing.T) {
+		externalFile := filepath.Join(tempDir, "chrome-bookmarks.custom")
+		if err := os.WriteFile(externalFile, []byte("not valid JSON"), 0644); err != nil {
+			t.Fatalf("Failed to write external test file: %v", err)
+		}
+
+		stub := &stubExternalLoader{
+			canLoad: func(path string) bool { return path == externalFile },
+			data:    &BookmarkData{Title: "From External Loader"},
+		}
+		RegisterExternalLoader(stub)
+		t.Cleanup(func() { RegisterExternalLoader(nil) })
+
+		result, err := loader.LoadBookmarksFromFile(externalFile)
+		if err != nil {
+			t.Fatalf("LoadBookmarksFromFile failed: %v", err)
+		}
+		if result.Title != "From External Loader" {
+			t.Errorf("Title = %v, want %v", result.Title, "From External Loader")
+		}
+	})
+}
+
+// stubExternalLoader is a test double for ExternalLoader.
+type stubExternalLoader struct {
+	canLoad func(path string) bool
+	data    *BookmarkData
+	err     error
+}
+
+func (s *stubExternalLoader) CanLoad(path string) bool { return s.canLoad(path) }
+
+func (s *stubExternalLoader) Load(path string) (*BookmarkData, error) {
+	return s.data, s.err
 }
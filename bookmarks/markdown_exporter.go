@@ -0,0 +1,76 @@
+package bookmarks
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownExporter exports bookmarks as a Markdown document, with folders
+// rendered as headings (capped at level 6) and bookmarks as a bullet list
+// of links.
+type MarkdownExporter struct{}
+
+// NewMarkdownExporter creates a new MarkdownExporter.
+func NewMarkdownExporter() *MarkdownExporter {
+	return &MarkdownExporter{}
+}
+
+// Export writes data to w as Markdown.
+func (e *MarkdownExporter) Export(w io.Writer, data *BookmarkData) error {
+	title := data.Title
+	if title == "" {
+		title = "Bookmarks Menu"
+	}
+	if _, err := fmt.Fprintf(w, "# %s\n\n", title); err != nil {
+		return fmt.Errorf("error writing Markdown title: %w", err)
+	}
+
+	return writeMarkdownChildren(w, data.Children, 2)
+}
+
+// writeMarkdownChildren writes children as Markdown, rendering folders as
+// level headings and bookmarks as links nested under them.
+func writeMarkdownChildren(w io.Writer, children []BookmarkData, level int) error {
+	for _, child := range children {
+		switch {
+		case child.TypeCode == BookmarkSeparatorType:
+			continue
+
+		case child.Children != nil:
+			headingLevel := level
+			if headingLevel > 6 {
+				headingLevel = 6
+			}
+			heading := fmt.Sprintf("\n%s %s\n\n", strings.Repeat("#", headingLevel), child.Title)
+			if _, err := io.WriteString(w, heading); err != nil {
+				return fmt.Errorf("error writing Markdown heading: %w", err)
+			}
+			if err := writeMarkdownChildren(w, child.Children, level+1); err != nil {
+				return err
+			}
+
+		case child.URI != "":
+			title := child.Title
+			if title == "" {
+				title = child.URI
+			}
+			if _, err := fmt.Fprintf(w, "- [%s](%s)\n", markdownEscape(title), child.URI); err != nil {
+				return fmt.Errorf("error writing Markdown link: %w", err)
+			}
+			if description := bookmarkDescription(&child); description != "" {
+				if _, err := fmt.Fprintf(w, "  > %s\n", description); err != nil {
+					return fmt.Errorf("error writing Markdown description: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// markdownEscape escapes characters with special meaning inside Markdown
+// link text ("[" and "]").
+func markdownEscape(text string) string {
+	replacer := strings.NewReplacer("[", "\\[", "]", "\\]")
+	return replacer.Replace(text)
+}
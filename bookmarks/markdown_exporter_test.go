@@ -0,0 +1,86 @@
+package bookmarks
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownExporter_Export(t *testing.T) {
+	data := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title: "Development",
+				Children: []BookmarkData{
+					{Title: "GitHub", URI: "https://github.com"},
+				},
+			},
+			{TypeCode: BookmarkSeparatorType},
+			{Title: "Example", URI: "https://example.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownExporter().Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	out := buf.String()
+
+	if !strings.Contains(out, "# Bookmarks Menu") {
+		t.Error("Expected top-level heading")
+	}
+	if !strings.Contains(out, "## Development") {
+		t.Error("Expected folder heading")
+	}
+	if !strings.Contains(out, "- [GitHub](https://github.com)") {
+		t.Errorf("Expected nested bookmark link, got: %q", out)
+	}
+	if !strings.Contains(out, "- [Example](https://example.com)") {
+		t.Errorf("Expected top-level bookmark link, got: %q", out)
+	}
+}
+
+func TestMarkdownExporter_Export_IncludesDescription(t *testing.T) {
+	data := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title: "GitHub",
+				URI:   "https://github.com",
+				Annotations: []Annotation{
+					{Name: netscapeDescriptionAnno, Value: "Where the code lives"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownExporter().Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "- [GitHub](https://github.com)\n  > Where the code lives\n") {
+		t.Errorf("Expected description under the bookmark link, got: %q", out)
+	}
+}
+
+func TestMarkdownExporter_Export_EscapesBrackets(t *testing.T) {
+	data := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{Title: "[Draft] Notes", URI: "https://example.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownExporter().Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `\[Draft\] Notes`) {
+		t.Errorf("Expected escaped brackets in link text, got: %q", buf.String())
+	}
+}
@@ -0,0 +1,279 @@
+package bookmarks
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// normalizeURI canonicalizes a bookmark URI for deduplication purposes: it
+// strips the fragment, lowercases the host, strips the scheme's default
+// port, strips a bare trailing slash, drops utm_* tracking query
+// parameters, and sorts the remaining query parameters so cosmetically
+// different URIs pointing at the same resource compare equal.
+func normalizeURI(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	parsed.Fragment = ""
+	parsed.Host = strings.ToLower(parsed.Host)
+
+	if port := parsed.Port(); port != "" {
+		if (parsed.Scheme == "http" && port == "80") || (parsed.Scheme == "https" && port == "443") {
+			parsed.Host = strings.TrimSuffix(parsed.Host, ":"+port)
+		}
+	}
+
+	if parsed.Path == "/" {
+		parsed.Path = ""
+	}
+
+	if parsed.RawQuery != "" {
+		values := parsed.Query()
+		for key := range values {
+			if strings.HasPrefix(key, "utm_") {
+				delete(values, key)
+			}
+		}
+
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		sortedPairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			for _, v := range values[k] {
+				sortedPairs = append(sortedPairs, k+"="+v)
+			}
+		}
+		parsed.RawQuery = strings.Join(sortedPairs, "&")
+	}
+
+	return parsed.String()
+}
+
+// unionAnnotations combines a and b, keeping the first Annotation seen for
+// each distinct Name.
+func unionAnnotations(a, b []Annotation) []Annotation {
+	seen := make(map[string]bool, len(a)+len(b))
+	out := make([]Annotation, 0, len(a)+len(b))
+
+	for _, anno := range append(append([]Annotation{}, a...), b...) {
+		if seen[anno.Name] {
+			continue
+		}
+		seen[anno.Name] = true
+		out = append(out, anno)
+	}
+
+	return out
+}
+
+// ConflictStrategy controls how BookmarkMerger resolves two folders that
+// share a title but have different children.
+type ConflictStrategy int
+
+const (
+	// MergeFolders recursively merges the colliding folders' children
+	// together (matching leaves by normalized URI and sub-folders by
+	// title, as usual). This is the default strategy.
+	MergeFolders ConflictStrategy = iota
+	// KeepFirst discards every later folder with the same title,
+	// keeping only the first tree's version of it untouched.
+	KeepFirst
+	// KeepNewest keeps whichever colliding folder has the newest
+	// LastModified, discarding the other entirely.
+	KeepNewest
+	// Rename keeps every colliding folder, appending " (2)", " (3)", ...
+	// to the title of each one after the first so none are lost.
+	Rename
+)
+
+// BookmarkMerger combines bookmark trees from multiple sources (Firefox
+// profiles, or other browsers imported via HTMLParser/sources) into one
+// deduplicated tree.
+type BookmarkMerger struct {
+	// Strategy controls what happens when two folders share a title but
+	// have different children. It has no effect on leaf bookmarks, which
+	// are always deduplicated by normalized URI: the earliest DateAdded
+	// and latest LastModified are kept, and Annotations are unioned.
+	Strategy ConflictStrategy
+}
+
+// NewBookmarkMerger creates a BookmarkMerger using the given folder
+// conflict strategy.
+func NewBookmarkMerger(strategy ConflictStrategy) *BookmarkMerger {
+	return &BookmarkMerger{Strategy: strategy}
+}
+
+// Merge unions trees into a single deduplicated tree.
+func (m *BookmarkMerger) Merge(trees ...*BookmarkData) *BookmarkData {
+	merged := &BookmarkData{Title: "Bookmarks Menu"}
+
+	childSets := make([][]BookmarkData, 0, len(trees))
+	for _, tree := range trees {
+		if tree == nil {
+			continue
+		}
+		childSets = append(childSets, tree.Children)
+	}
+
+	merged.Children = m.mergeChildren(childSets)
+	return merged
+}
+
+// mergeEntry accumulates the data for one merged child across trees, plus
+// the child-slices (for folders) still waiting to be merged recursively.
+type mergeEntry struct {
+	data      BookmarkData
+	isFolder  bool
+	childSets [][]BookmarkData
+}
+
+// mergeChildren folds childSets into a single, deduplicated slice. Folders
+// are matched by case-insensitive title and resolved per m.Strategy; leaves
+// are matched by normalized URI, keeping the earliest DateAdded, latest
+// LastModified, and unioning Annotations by name.
+func (m *BookmarkMerger) mergeChildren(childSets [][]BookmarkData) []BookmarkData {
+	var order []string
+	entries := make(map[string]*mergeEntry)
+	renameCounts := make(map[string]int)
+
+	for _, children := range childSets {
+		for _, child := range children {
+			if child.TypeCode == BookmarkSeparatorType {
+				continue
+			}
+
+			isFolder := child.Children != nil
+			if !isFolder {
+				m.mergeLeaf(&order, entries, child)
+				continue
+			}
+
+			key := "folder:" + strings.ToLower(child.Title)
+			existing, ok := entries[key]
+			if !ok {
+				entries[key] = &mergeEntry{data: child, isFolder: true, childSets: [][]BookmarkData{child.Children}}
+				order = append(order, key)
+				continue
+			}
+
+			switch m.Strategy {
+			case KeepFirst:
+				// Keep the first occurrence untouched; drop this one.
+
+			case KeepNewest:
+				if child.LastModified > existing.data.LastModified {
+					existing.data = child
+					existing.childSets = [][]BookmarkData{child.Children}
+				}
+
+			case Rename:
+				renameCounts[key]++
+				renamed := child
+				renamed.Title = fmt.Sprintf("%s (%d)", child.Title, renameCounts[key]+1)
+				renamedKey := fmt.Sprintf("%s#%d", key, renameCounts[key])
+				entries[renamedKey] = &mergeEntry{data: renamed, isFolder: true, childSets: [][]BookmarkData{renamed.Children}}
+				order = append(order, renamedKey)
+
+			default: // MergeFolders
+				existing.childSets = append(existing.childSets, child.Children)
+			}
+		}
+	}
+
+	result := make([]BookmarkData, 0, len(order))
+	for _, key := range order {
+		entry := entries[key]
+		if entry.isFolder {
+			entry.data.Children = m.mergeChildren(entry.childSets)
+		}
+		result = append(result, entry.data)
+	}
+	return result
+}
+
+// mergeLeaf folds a single bookmark into entries/order, deduplicating by
+// normalized URI: the earliest DateAdded and latest LastModified are kept,
+// Annotations are unioned, and the remaining fields (title, URI, ...) come
+// from whichever duplicate has the newest LastModified.
+func (m *BookmarkMerger) mergeLeaf(order *[]string, entries map[string]*mergeEntry, child BookmarkData) {
+	key := "leaf:" + normalizeURI(child.URI)
+
+	existing, ok := entries[key]
+	if !ok {
+		entries[key] = &mergeEntry{data: child}
+		*order = append(*order, key)
+		return
+	}
+
+	annotations := unionAnnotations(existing.data.Annotations, child.Annotations)
+
+	dateAdded := existing.data.DateAdded
+	if dateAdded == 0 || (child.DateAdded != 0 && child.DateAdded < dateAdded) {
+		dateAdded = child.DateAdded
+	}
+
+	lastModified := existing.data.LastModified
+	if child.LastModified > lastModified {
+		lastModified = child.LastModified
+	}
+
+	winner := existing.data
+	if child.LastModified > existing.data.LastModified {
+		winner = child
+	}
+
+	winner.Annotations = annotations
+	winner.DateAdded = dateAdded
+	winner.LastModified = lastModified
+	existing.data = winner
+}
+
+// MergeBookmarks unions the given bookmark trees into a single tree using
+// the default MergeFolders strategy. It is equivalent to
+// NewBookmarkMerger(MergeFolders).Merge(trees...).
+func MergeBookmarks(trees ...*BookmarkData) *BookmarkData {
+	return NewBookmarkMerger(MergeFolders).Merge(trees...)
+}
+
+// FilterSince returns a copy of data with leaf bookmarks whose DateAdded
+// predates since removed. Folders left with no remaining children after
+// filtering are dropped as well.
+func FilterSince(data *BookmarkData, since time.Time) *BookmarkData {
+	result := *data
+	result.Children = filterSinceChildren(data.Children, since.UnixMicro())
+	return &result
+}
+
+// filterSinceChildren is the recursive helper behind FilterSince.
+func filterSinceChildren(children []BookmarkData, cutoff int64) []BookmarkData {
+	var result []BookmarkData
+
+	for _, child := range children {
+		if child.Children != nil {
+			filteredChildren := filterSinceChildren(child.Children, cutoff)
+			if len(filteredChildren) == 0 {
+				continue
+			}
+			folder := child
+			folder.Children = filteredChildren
+			result = append(result, folder)
+			continue
+		}
+
+		if child.DateAdded != 0 && child.DateAdded < cutoff {
+			continue
+		}
+		result = append(result, child)
+	}
+
+	return result
+}
@@ -0,0 +1,192 @@
+package bookmarks
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeBookmarks_DedupLeavesByNormalizedURI(t *testing.T) {
+	treeA := &BookmarkData{
+		Children: []BookmarkData{
+			{
+				Title:        "GitHub",
+				URI:          "https://GitHub.com/?b=2&a=1#frag",
+				LastModified: 100,
+				Annotations:  []Annotation{{Name: "bookmarkProperties/description", Value: "old"}},
+			},
+		},
+	}
+	treeB := &BookmarkData{
+		Children: []BookmarkData{
+			{
+				Title:        "GitHub (updated)",
+				URI:          "https://github.com/?a=1&b=2",
+				LastModified: 200,
+				Annotations:  []Annotation{{Name: "tag", Value: "dev"}},
+			},
+		},
+	}
+
+	merged := MergeBookmarks(treeA, treeB)
+
+	if len(merged.Children) != 1 {
+		t.Fatalf("Children length = %v, want %v", len(merged.Children), 1)
+	}
+
+	got := merged.Children[0]
+	if got.Title != "GitHub (updated)" {
+		t.Errorf("Title = %q, want %q (newest LastModified should win)", got.Title, "GitHub (updated)")
+	}
+	if len(got.Annotations) != 2 {
+		t.Errorf("Annotations = %+v, want 2 unioned entries", got.Annotations)
+	}
+}
+
+func TestMergeBookmarks_MergesFoldersByTitle(t *testing.T) {
+	treeA := &BookmarkData{
+		Children: []BookmarkData{
+			{
+				Title:    "Development",
+				Children: []BookmarkData{{Title: "GitHub", URI: "https://github.com"}},
+			},
+		},
+	}
+	treeB := &BookmarkData{
+		Children: []BookmarkData{
+			{
+				Title:    "development",
+				Children: []BookmarkData{{Title: "GitLab", URI: "https://gitlab.com"}},
+			},
+		},
+	}
+
+	merged := MergeBookmarks(treeA, treeB)
+
+	if len(merged.Children) != 1 {
+		t.Fatalf("Children length = %v, want %v (folders should merge case-insensitively)", len(merged.Children), 1)
+	}
+	if len(merged.Children[0].Children) != 2 {
+		t.Errorf("merged folder Children length = %v, want %v", len(merged.Children[0].Children), 2)
+	}
+}
+
+func TestNormalizeURI(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{
+		{"DefaultHTTPSPort", "https://example.com:443/path", "https://example.com/path"},
+		{"DefaultHTTPPort", "http://example.com:80/path", "http://example.com/path"},
+		{"TrailingSlash", "https://example.com/", "https://example.com"},
+		{"UTMParamsStripped", "https://example.com?utm_source=x&a=1", "https://example.com?a=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := normalizeURI(tt.a), normalizeURI(tt.b); got != want {
+				t.Errorf("normalizeURI(%q) = %q, normalizeURI(%q) = %q, want equal", tt.a, got, tt.b, want)
+			}
+		})
+	}
+}
+
+func TestBookmarkMerger_KeepFirst(t *testing.T) {
+	treeA := &BookmarkData{
+		Children: []BookmarkData{
+			{Title: "Development", Children: []BookmarkData{{Title: "GitHub", URI: "https://github.com"}}},
+		},
+	}
+	treeB := &BookmarkData{
+		Children: []BookmarkData{
+			{Title: "Development", Children: []BookmarkData{{Title: "GitLab", URI: "https://gitlab.com"}}},
+		},
+	}
+
+	merged := NewBookmarkMerger(KeepFirst).Merge(treeA, treeB)
+
+	if len(merged.Children) != 1 || len(merged.Children[0].Children) != 1 {
+		t.Fatalf("expected only treeA's Development folder to survive, got %+v", merged.Children)
+	}
+	if merged.Children[0].Children[0].Title != "GitHub" {
+		t.Errorf("Title = %q, want %q", merged.Children[0].Children[0].Title, "GitHub")
+	}
+}
+
+func TestBookmarkMerger_KeepNewest(t *testing.T) {
+	treeA := &BookmarkData{
+		Children: []BookmarkData{
+			{
+				Title:        "Development",
+				LastModified: 100,
+				Children:     []BookmarkData{{Title: "GitHub", URI: "https://github.com"}},
+			},
+		},
+	}
+	treeB := &BookmarkData{
+		Children: []BookmarkData{
+			{
+				Title:        "Development",
+				LastModified: 200,
+				Children:     []BookmarkData{{Title: "GitLab", URI: "https://gitlab.com"}},
+			},
+		},
+	}
+
+	merged := NewBookmarkMerger(KeepNewest).Merge(treeA, treeB)
+
+	if len(merged.Children) != 1 || len(merged.Children[0].Children) != 1 {
+		t.Fatalf("expected only treeB's (newest) Development folder to survive, got %+v", merged.Children)
+	}
+	if merged.Children[0].Children[0].Title != "GitLab" {
+		t.Errorf("Title = %q, want %q", merged.Children[0].Children[0].Title, "GitLab")
+	}
+}
+
+func TestBookmarkMerger_Rename(t *testing.T) {
+	treeA := &BookmarkData{
+		Children: []BookmarkData{
+			{Title: "Development", Children: []BookmarkData{{Title: "GitHub", URI: "https://github.com"}}},
+		},
+	}
+	treeB := &BookmarkData{
+		Children: []BookmarkData{
+			{Title: "Development", Children: []BookmarkData{{Title: "GitLab", URI: "https://gitlab.com"}}},
+		},
+	}
+
+	merged := NewBookmarkMerger(Rename).Merge(treeA, treeB)
+
+	if len(merged.Children) != 2 {
+		t.Fatalf("expected both Development folders to survive separately, got %+v", merged.Children)
+	}
+	if merged.Children[0].Title != "Development" || merged.Children[1].Title != "Development (2)" {
+		t.Errorf("Titles = %q, %q, want %q, %q", merged.Children[0].Title, merged.Children[1].Title, "Development", "Development (2)")
+	}
+}
+
+func TestFilterSince_DropsOlderLeavesAndEmptyFolders(t *testing.T) {
+	cutoff := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	data := &BookmarkData{
+		Children: []BookmarkData{
+			{Title: "Old", URI: "https://old.example.com", DateAdded: cutoff.Add(-24 * time.Hour).UnixMicro()},
+			{Title: "New", URI: "https://new.example.com", DateAdded: cutoff.Add(24 * time.Hour).UnixMicro()},
+			{
+				Title: "AllOld",
+				Children: []BookmarkData{
+					{Title: "Old2", URI: "https://old2.example.com", DateAdded: cutoff.Add(-time.Hour).UnixMicro()},
+				},
+			},
+		},
+	}
+
+	result := FilterSince(data, cutoff)
+
+	if len(result.Children) != 1 {
+		t.Fatalf("Children length = %v, want %v", len(result.Children), 1)
+	}
+	if result.Children[0].Title != "New" {
+		t.Errorf("Title = %q, want %q", result.Children[0].Title, "New")
+	}
+}
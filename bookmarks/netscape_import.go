@@ -0,0 +1,187 @@
+package bookmarks
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// netscapeDescriptionAnno is the annotation name used for bookmark
+// descriptions, matching the one HTMLConverter reads when writing <DD> tags.
+const netscapeDescriptionAnno = "bookmarkProperties/description"
+
+// Netscape attributes that have no dedicated BookmarkData field are carried
+// through as Annotations under these names, so they survive in the
+// BookmarkData tree (and in JSON/jsonlz4 output). HTMLExporter does not
+// write them back out.
+const (
+	netscapeIconAnno        = "netscape/icon"
+	netscapeIconURIAnno     = "netscape/iconUri"
+	netscapeTagsAnno        = "netscape/tags"
+	netscapePrivateAnno     = "netscape/private"
+	netscapeShortcutURLAnno = "netscape/shortcutUrl"
+)
+
+// ImportFromNetscapeHTML parses a Netscape Bookmark File (the format written
+// by HTMLExporter, and produced by Firefox, Chrome, Safari and most other
+// browsers) from r and reconstructs the BookmarkData tree it describes. It
+// is equivalent to NewHTMLParser().Parse(r).
+func ImportFromNetscapeHTML(r io.Reader) (*BookmarkData, error) {
+	return NewHTMLParser().Parse(r)
+}
+
+// findElement returns the first descendant of n (including n itself) whose
+// tag name matches tag, using depth-first traversal.
+func findElement(n *html.Node, tag string) *html.Node {
+	if n.Type == html.ElementNode && n.Data == tag {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// parseDL walks the <DT>/<DD> children of a <DL> element and returns the
+// BookmarkData entries they describe, recursing into nested <DL> elements
+// for folder children.
+func parseDL(dl *html.Node) []BookmarkData {
+	var entries []BookmarkData
+
+	for dt := dl.FirstChild; dt != nil; dt = dt.NextSibling {
+		if dt.Type != html.ElementNode || dt.Data != "dt" {
+			continue
+		}
+
+		child := firstElementChild(dt)
+		if child == nil {
+			continue
+		}
+
+		switch child.Data {
+		case "h3":
+			folder := BookmarkData{Title: textContent(child)}
+			applyDateAttrs(&folder, child)
+
+			// The Netscape format never closes <DT>, so the HTML5 parser
+			// nests a folder's <DL> as a child of its <DT>, not a sibling.
+			if nested := findElement(dt, "dl"); nested != nil {
+				folder.Children = parseDL(nested)
+			}
+			entries = append(entries, folder)
+
+		case "a":
+			bookmark := BookmarkData{
+				Title: textContent(child),
+				URI:   attr(child, "href"),
+			}
+			applyDateAttrs(&bookmark, child)
+			applyAnchorAnnotations(&bookmark, child)
+
+			if dd := findSiblingElement(dt, "dd"); dd != nil {
+				bookmark.Annotations = append(bookmark.Annotations, Annotation{
+					Name:  netscapeDescriptionAnno,
+					Value: strings.TrimSpace(textContent(dd)),
+				})
+			}
+			entries = append(entries, bookmark)
+		}
+	}
+
+	return entries
+}
+
+// findSiblingElement returns the next sibling of n matching tag, stopping
+// at the next <dt> boundary so a bookmark's <DD> isn't mistaken for one
+// belonging to a later entry.
+func findSiblingElement(n *html.Node, tag string) *html.Node {
+	for s := n.NextSibling; s != nil; s = s.NextSibling {
+		if s.Type != html.ElementNode {
+			continue
+		}
+		if s.Data == tag {
+			return s
+		}
+		if s.Data == "dt" {
+			return nil
+		}
+	}
+	return nil
+}
+
+// firstElementChild returns n's first child that is an element node.
+func firstElementChild(n *html.Node) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode {
+			return c
+		}
+	}
+	return nil
+}
+
+// textContent concatenates the text of all descendant text nodes of n.
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			sb.WriteString(node.Data)
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+// attr returns the value of n's attribute named key, or "" if absent.
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// applyAnchorAnnotations reads the ICON, ICON_URI, TAGS, PRIVATE and
+// SHORTCUTURL attributes from a Netscape <A> element, which have no
+// dedicated BookmarkData field, and records them as Annotations.
+func applyAnchorAnnotations(data *BookmarkData, node *html.Node) {
+	if icon := attr(node, "icon"); icon != "" {
+		data.Annotations = append(data.Annotations, Annotation{Name: netscapeIconAnno, Value: icon})
+	}
+	if iconURI := attr(node, "icon_uri"); iconURI != "" {
+		data.Annotations = append(data.Annotations, Annotation{Name: netscapeIconURIAnno, Value: iconURI})
+	}
+	if tags := attr(node, "tags"); tags != "" {
+		data.Annotations = append(data.Annotations, Annotation{Name: netscapeTagsAnno, Value: tags})
+	}
+	if private := attr(node, "private"); private != "" {
+		data.Annotations = append(data.Annotations, Annotation{Name: netscapePrivateAnno, Value: private})
+	}
+	if shortcut := attr(node, "shortcuturl"); shortcut != "" {
+		data.Annotations = append(data.Annotations, Annotation{Name: netscapeShortcutURLAnno, Value: shortcut})
+	}
+}
+
+// applyDateAttrs reads ADD_DATE/LAST_MODIFIED (seconds since the Unix
+// epoch, as written by HTMLExporter) from node and sets the equivalent
+// Firefox PRTime (microseconds) fields on data.
+func applyDateAttrs(data *BookmarkData, node *html.Node) {
+	if added := attr(node, "add_date"); added != "" {
+		if seconds, err := strconv.ParseInt(added, 10, 64); err == nil {
+			data.DateAdded = seconds * 1000000
+		}
+	}
+	if modified := attr(node, "last_modified"); modified != "" {
+		if seconds, err := strconv.ParseInt(modified, 10, 64); err == nil {
+			data.LastModified = seconds * 1000000
+		}
+	}
+}
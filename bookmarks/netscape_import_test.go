@@ -0,0 +1,76 @@
+package bookmarks
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestImportFromNetscapeHTML_RoundTrip(t *testing.T) {
+	original := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title:     "Development",
+				DateAdded: 1639123456000000,
+				Children: []BookmarkData{
+					{
+						Title:        "GitHub",
+						URI:          "https://github.com",
+						DateAdded:    1639123456000000,
+						LastModified: 1639123457000000,
+						Annotations: []Annotation{
+							{Name: netscapeDescriptionAnno, Value: "Code hosting"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var htmlBuf bytes.Buffer
+	if err := ConvertBookmarksToHTML(&htmlBuf, &original); err != nil {
+		t.Fatalf("ConvertBookmarksToHTML failed: %v", err)
+	}
+
+	result, err := ImportFromNetscapeHTML(&htmlBuf)
+	if err != nil {
+		t.Fatalf("ImportFromNetscapeHTML failed: %v", err)
+	}
+
+	if len(result.Children) != 1 {
+		t.Fatalf("Children length = %v, want %v", len(result.Children), 1)
+	}
+
+	folder := result.Children[0]
+	if folder.Title != "Development" {
+		t.Errorf("folder Title = %q, want %q", folder.Title, "Development")
+	}
+	if folder.DateAdded != original.Children[0].DateAdded {
+		t.Errorf("folder DateAdded = %v, want %v", folder.DateAdded, original.Children[0].DateAdded)
+	}
+	if len(folder.Children) != 1 {
+		t.Fatalf("folder Children length = %v, want %v", len(folder.Children), 1)
+	}
+
+	bookmark := folder.Children[0]
+	if bookmark.Title != "GitHub" {
+		t.Errorf("bookmark Title = %q, want %q", bookmark.Title, "GitHub")
+	}
+	if bookmark.URI != "https://github.com" {
+		t.Errorf("bookmark URI = %q, want %q", bookmark.URI, "https://github.com")
+	}
+	if bookmark.DateAdded != original.Children[0].Children[0].DateAdded {
+		t.Errorf("bookmark DateAdded = %v, want %v", bookmark.DateAdded, original.Children[0].Children[0].DateAdded)
+	}
+	if len(bookmark.Annotations) != 1 || bookmark.Annotations[0].Value != "Code hosting" {
+		t.Errorf("bookmark Annotations = %+v, want description %q", bookmark.Annotations, "Code hosting")
+	}
+}
+
+func TestImportFromNetscapeHTML_NoBookmarkList(t *testing.T) {
+	_, err := ImportFromNetscapeHTML(strings.NewReader("<html><body>no bookmarks here</body></html>"))
+	if err == nil {
+		t.Error("Expected error when no <DL> is present")
+	}
+}
@@ -0,0 +1,68 @@
+package bookmarks
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HTMLParser parses Netscape Bookmark File HTML, as exported by Firefox,
+// Chrome, Safari, Pocket, Pinboard, Shiori and most other bookmark tools,
+// into a BookmarkData tree. Parse followed by ConvertBookmarksToHTML keeps
+// folders, links, titles, ADD_DATE/LAST_MODIFIED and <DD> descriptions; the
+// ICON, ICON_URI, TAGS, PRIVATE and SHORTCUTURL attributes are kept as
+// Annotations on the tree but are not written back out, and any other
+// attributes are dropped.
+type HTMLParser struct{}
+
+// NewHTMLParser creates a new HTMLParser.
+func NewHTMLParser() *HTMLParser {
+	return &HTMLParser{}
+}
+
+// Parse reads a Netscape Bookmark File from r and returns the BookmarkData
+// tree it describes. The underlying HTML5 parser tolerates the unclosed
+// <DT>/<P> tags most exporters emit, and decodes HTML entities in titles,
+// URLs and descriptions automatically.
+func (p *HTMLParser) Parse(r io.Reader) (*BookmarkData, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Netscape HTML: %w", err)
+	}
+
+	if doctype := findDoctype(doc); doctype != nil && !strings.EqualFold(doctype.Data, "NETSCAPE-Bookmark-file-1") {
+		return nil, fmt.Errorf("unexpected doctype %q, expected NETSCAPE-Bookmark-file-1", doctype.Data)
+	}
+
+	dl := findElement(doc, "dl")
+	if dl == nil {
+		return nil, fmt.Errorf("no bookmark list (<DL>) found in document")
+	}
+
+	title := "Bookmarks Menu"
+	if h1 := findElement(doc, "h1"); h1 != nil {
+		if text := strings.TrimSpace(textContent(h1)); text != "" {
+			title = text
+		}
+	}
+
+	return &BookmarkData{
+		Title:    title,
+		Children: parseDL(dl),
+	}, nil
+}
+
+// findDoctype returns the document's DOCTYPE node, or nil if it has none.
+func findDoctype(n *html.Node) *html.Node {
+	if n.Type == html.DoctypeNode {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findDoctype(c); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package bookmarks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLParser_Parse_ExtraAnchorAttributes(t *testing.T) {
+	htmlDoc := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://example.com" ADD_DATE="1000" ICON="data:image/png;base64,AA==" TAGS="go,cli" PRIVATE="1" SHORTCUTURL="ex">Example</A>
+</DL><p>`
+
+	result, err := NewHTMLParser().Parse(strings.NewReader(htmlDoc))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(result.Children) != 1 {
+		t.Fatalf("Children length = %v, want %v", len(result.Children), 1)
+	}
+
+	bookmark := result.Children[0]
+	annos := map[string]string{}
+	for _, a := range bookmark.Annotations {
+		annos[a.Name] = a.Value
+	}
+
+	if annos[netscapeIconAnno] == "" {
+		t.Error("expected ICON to be captured as an annotation")
+	}
+	if annos[netscapeTagsAnno] != "go,cli" {
+		t.Errorf("TAGS annotation = %q, want %q", annos[netscapeTagsAnno], "go,cli")
+	}
+	if annos[netscapePrivateAnno] != "1" {
+		t.Errorf("PRIVATE annotation = %q, want %q", annos[netscapePrivateAnno], "1")
+	}
+	if annos[netscapeShortcutURLAnno] != "ex" {
+		t.Errorf("SHORTCUTURL annotation = %q, want %q", annos[netscapeShortcutURLAnno], "ex")
+	}
+}
+
+func TestHTMLParser_Parse_RejectsWrongDoctype(t *testing.T) {
+	htmlDoc := `<!DOCTYPE html>
+<DL><p><DT><A HREF="https://example.com">Example</A></DL><p>`
+
+	_, err := NewHTMLParser().Parse(strings.NewReader(htmlDoc))
+	if err == nil {
+		t.Error("Expected error for a non-Netscape doctype")
+	}
+}
+
+func TestHTMLParser_Parse_TolerantOfUnclosedTags(t *testing.T) {
+	// Real-world exporters (including this package's own HTMLExporter)
+	// never close <DT> or the top-level <p>, relying on the HTML5 parsing
+	// algorithm's implied-end-tag rules for <dt>/<dd>.
+	htmlDoc := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><H3>Folder</H3>
+    <DL><p>
+        <DT><A HREF="https://example.com">Example</A>
+    </DL><p>
+</DL><p>`
+
+	result, err := NewHTMLParser().Parse(strings.NewReader(htmlDoc))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(result.Children) != 1 || result.Children[0].Title != "Folder" {
+		t.Fatalf("expected a single Folder child, got %+v", result.Children)
+	}
+	if len(result.Children[0].Children) != 1 || result.Children[0].Children[0].URI != "https://example.com" {
+		t.Fatalf("expected Folder to contain the Example bookmark, got %+v", result.Children[0].Children)
+	}
+}
+
+func TestHTMLParser_Parse_ExtractsRootTitle(t *testing.T) {
+	htmlDoc := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<H1>My Bookmarks</H1>
+<DL><p></DL><p>`
+
+	result, err := NewHTMLParser().Parse(strings.NewReader(htmlDoc))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if result.Title != "My Bookmarks" {
+		t.Errorf("Title = %q, want %q", result.Title, "My Bookmarks")
+	}
+}
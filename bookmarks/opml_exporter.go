@@ -0,0 +1,102 @@
+package bookmarks
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// opmlDocument is the root <opml> element written by OPMLExporter.
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    opmlHead `xml:"head"`
+	Body    opmlBody `xml:"body"`
+}
+
+type opmlHead struct {
+	Title string `xml:"title"`
+}
+
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlOutline is a single <outline> node: a folder (nested outlines) or a
+// bookmark (type="link", with a url attribute), following the convention
+// used by browsers that support importing/exporting OPML bookmark files.
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	URL      string        `xml:"url,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline,omitempty"`
+}
+
+// OPMLExporter exports bookmarks as an OPML 2.0 document, with folders as
+// nested <outline> elements and bookmarks as type="link" outlines.
+type OPMLExporter struct{}
+
+// NewOPMLExporter creates a new OPMLExporter.
+func NewOPMLExporter() *OPMLExporter {
+	return &OPMLExporter{}
+}
+
+// Export writes data to w as an OPML 2.0 document.
+func (e *OPMLExporter) Export(w io.Writer, data *BookmarkData) error {
+	title := data.Title
+	if title == "" {
+		title = "Bookmarks Menu"
+	}
+
+	doc := opmlDocument{
+		Version: "2.0",
+		Head:    opmlHead{Title: title},
+		Body:    opmlBody{Outlines: toOPMLOutlines(data.Children)},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("error writing OPML header: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("error encoding OPML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// toOPMLOutlines converts children into OPML outlines, recursing into
+// folders and skipping separators.
+func toOPMLOutlines(children []BookmarkData) []opmlOutline {
+	var outlines []opmlOutline
+
+	for _, child := range children {
+		switch {
+		case child.TypeCode == BookmarkSeparatorType:
+			continue
+
+		case child.Children != nil:
+			outlines = append(outlines, opmlOutline{
+				Text:     child.Title,
+				Outlines: toOPMLOutlines(child.Children),
+			})
+
+		case child.URI != "":
+			title := child.Title
+			if title == "" {
+				title = child.URI
+			}
+			outlines = append(outlines, opmlOutline{
+				Text:  title,
+				Title: title,
+				Type:  "link",
+				URL:   child.URI,
+			})
+		}
+	}
+
+	return outlines
+}
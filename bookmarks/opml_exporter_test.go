@@ -0,0 +1,63 @@
+package bookmarks
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestOPMLExporter_Export(t *testing.T) {
+	data := BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title: "Development",
+				Children: []BookmarkData{
+					{Title: "GitHub", URI: "https://github.com"},
+				},
+			},
+			{TypeCode: BookmarkSeparatorType},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewOPMLExporter().Export(&buf, &data); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	var doc opmlDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal exported OPML: %v", err)
+	}
+
+	if doc.Version != "2.0" {
+		t.Errorf("Version = %q, want %q", doc.Version, "2.0")
+	}
+	if doc.Head.Title != "Bookmarks Menu" {
+		t.Errorf("Head.Title = %q, want %q", doc.Head.Title, "Bookmarks Menu")
+	}
+	if len(doc.Body.Outlines) != 1 {
+		t.Fatalf("Outlines length = %v, want %v (separator should be skipped)", len(doc.Body.Outlines), 1)
+	}
+
+	folder := doc.Body.Outlines[0]
+	if folder.Text != "Development" {
+		t.Errorf("folder Text = %q, want %q", folder.Text, "Development")
+	}
+	if len(folder.Outlines) != 1 {
+		t.Fatalf("folder Outlines length = %v, want %v", len(folder.Outlines), 1)
+	}
+
+	bookmark := folder.Outlines[0]
+	if bookmark.Type != "link" {
+		t.Errorf("bookmark Type = %q, want %q", bookmark.Type, "link")
+	}
+	if bookmark.URL != "https://github.com" {
+		t.Errorf("bookmark URL = %q, want %q", bookmark.URL, "https://github.com")
+	}
+
+	if !strings.Contains(buf.String(), `<?xml version="1.0" encoding="UTF-8"?>`) {
+		t.Error("Expected XML declaration in output")
+	}
+}
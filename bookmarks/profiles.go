@@ -0,0 +1,170 @@
+package bookmarks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+// Browser identifies which browser a discovered Profile belongs to.
+type Browser string
+
+const (
+	BrowserFirefox  Browser = "firefox"
+	BrowserChrome   Browser = "chrome"
+	BrowserChromium Browser = "chromium"
+	BrowserEdge     Browser = "edge"
+)
+
+// Profile describes a single browser profile directory found on disk by
+// DiscoverProfiles.
+type Profile struct {
+	Browser Browser
+	// Name is the profile directory's base name, e.g. "xxxxxxxx.default-release".
+	Name string
+	// Path is the profile directory itself.
+	Path string
+	// LatestBackup is the most recent bookmark file found for this
+	// profile: the newest *.jsonlz4 under Path/bookmarkbackups for
+	// Firefox, or Path/Bookmarks for Chrome/Chromium/Edge. It is "" if
+	// no bookmark file was found.
+	LatestBackup string
+}
+
+// profileSource describes where one browser keeps its profile directories
+// on the current OS.
+type profileSource struct {
+	browser      Browser
+	profilesGlob string
+	// firefoxStyle is true for browsers that keep timestamped backups
+	// under a bookmarkbackups subdirectory (only Firefox today), false
+	// for browsers that keep a single "Bookmarks" file in the profile.
+	firefoxStyle bool
+}
+
+// profileSourcesForOS returns where to look for each supported browser's
+// profiles on the current OS.
+func profileSourcesForOS() ([]profileSource, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("error locating home directory: %w", err)
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		appSupport := filepath.Join(home, "Library", "Application Support")
+		return []profileSource{
+			{BrowserFirefox, filepath.Join(appSupport, "Firefox", "Profiles", "*"), true},
+			{BrowserChrome, filepath.Join(appSupport, "Google", "Chrome", "*"), false},
+			{BrowserChromium, filepath.Join(appSupport, "Chromium", "*"), false},
+			{BrowserEdge, filepath.Join(appSupport, "Microsoft Edge", "*"), false},
+		}, nil
+
+	case "windows":
+		appData := os.Getenv("APPDATA")
+		localAppData := os.Getenv("LOCALAPPDATA")
+		return []profileSource{
+			{BrowserFirefox, filepath.Join(appData, "Mozilla", "Firefox", "Profiles", "*"), true},
+			{BrowserChrome, filepath.Join(localAppData, "Google", "Chrome", "User Data", "*"), false},
+			{BrowserChromium, filepath.Join(localAppData, "Chromium", "User Data", "*"), false},
+			{BrowserEdge, filepath.Join(localAppData, "Microsoft", "Edge", "User Data", "*"), false},
+		}, nil
+
+	default: // linux and other unix-likes
+		return []profileSource{
+			{BrowserFirefox, filepath.Join(home, ".mozilla", "firefox", "*.default*"), true},
+			{BrowserChrome, filepath.Join(home, ".config", "google-chrome", "*"), false},
+			{BrowserChromium, filepath.Join(home, ".config", "chromium", "*"), false},
+			{BrowserEdge, filepath.Join(home, ".config", "microsoft-edge", "*"), false},
+		}, nil
+	}
+}
+
+// DiscoverProfiles locates installed Firefox, Chrome, Chromium and Edge
+// profiles on disk, so callers don't need to know where a given browser
+// keeps its bookmarks, and reports the most recent bookmark backup file
+// found for each profile.
+func DiscoverProfiles() ([]Profile, error) {
+	sources, err := profileSourcesForOS()
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []Profile
+	for _, source := range sources {
+		matches, err := filepath.Glob(source.profilesGlob)
+		if err != nil {
+			continue
+		}
+
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil || !info.IsDir() {
+				continue
+			}
+
+			profile := Profile{
+				Browser: source.browser,
+				Name:    filepath.Base(path),
+				Path:    path,
+			}
+			if source.firefoxStyle {
+				profile.LatestBackup = latestFirefoxBackup(path)
+			} else {
+				profile.LatestBackup = latestChromiumBookmarksFile(path)
+			}
+			profiles = append(profiles, profile)
+		}
+	}
+
+	return profiles, nil
+}
+
+// latestFirefoxBackup returns the most recently modified *.jsonlz4 file
+// under profilePath/bookmarkbackups, or "" if none exist.
+func latestFirefoxBackup(profilePath string) string {
+	matches, err := filepath.Glob(filepath.Join(profilePath, "bookmarkbackups", "*.jsonlz4"))
+	if err != nil || len(matches) == 0 {
+		return ""
+	}
+	return newestByModTime(matches)
+}
+
+// latestChromiumBookmarksFile returns profilePath/Bookmarks if it exists.
+func latestChromiumBookmarksFile(profilePath string) string {
+	path := filepath.Join(profilePath, "Bookmarks")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// newestByModTime returns whichever path in paths has the most recent
+// modification time.
+func newestByModTime(paths []string) string {
+	sort.Slice(paths, func(i, j int) bool {
+		iInfo, iErr := os.Stat(paths[i])
+		jInfo, jErr := os.Stat(paths[j])
+		if iErr != nil || jErr != nil {
+			return false
+		}
+		return iInfo.ModTime().After(jInfo.ModTime())
+	})
+	return paths[0]
+}
+
+// LoadLatestBackup loads profile's most recently modified bookmark backup.
+// Only Firefox profiles are supported here: Chrome/Chromium/Edge's
+// "Bookmarks" file needs bookmarks/sources.LoaderRegistry to parse, since
+// that package depends on this one rather than the reverse.
+func LoadLatestBackup(profile Profile) (*BookmarkData, error) {
+	if profile.LatestBackup == "" {
+		return nil, fmt.Errorf("no bookmark backup found for profile %q", profile.Name)
+	}
+	if profile.Browser != BrowserFirefox {
+		return nil, fmt.Errorf("profile %q: loading %s bookmarks requires bookmarks/sources.LoaderRegistry", profile.Name, profile.Browser)
+	}
+	return NewBookmarkLoader().LoadBookmarksFromFile(profile.LatestBackup)
+}
@@ -0,0 +1,108 @@
+package bookmarks
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// writeFile creates path's parent directories and writes contents to it.
+func writeFile(t *testing.T, path string, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestDiscoverProfiles(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("profile layout fixture only covers the Linux paths")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	firefoxProfile := filepath.Join(home, ".mozilla", "firefox", "abc123.default-release")
+	older := filepath.Join(firefoxProfile, "bookmarkbackups", "bookmarks-2025-01-01.jsonlz4")
+	newer := filepath.Join(firefoxProfile, "bookmarkbackups", "bookmarks-2025-06-11.jsonlz4")
+	writeFile(t, older, "old backup")
+	writeFile(t, newer, "new backup")
+	now := time.Now()
+	if err := os.Chtimes(older, now.Add(-time.Hour), now.Add(-time.Hour)); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+	if err := os.Chtimes(newer, now, now); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+
+	chromeProfile := filepath.Join(home, ".config", "google-chrome", "Default")
+	writeFile(t, filepath.Join(chromeProfile, "Bookmarks"), sampleChromiumBookmarksJSON)
+
+	profiles, err := DiscoverProfiles()
+	if err != nil {
+		t.Fatalf("DiscoverProfiles failed: %v", err)
+	}
+
+	var firefox, chrome *Profile
+	for i := range profiles {
+		switch profiles[i].Path {
+		case firefoxProfile:
+			firefox = &profiles[i]
+		case chromeProfile:
+			chrome = &profiles[i]
+		}
+	}
+
+	if firefox == nil {
+		t.Fatalf("expected a discovered Firefox profile at %s, got %+v", firefoxProfile, profiles)
+	}
+	if firefox.Browser != BrowserFirefox {
+		t.Errorf("Browser = %v, want %v", firefox.Browser, BrowserFirefox)
+	}
+	if firefox.LatestBackup != newer {
+		t.Errorf("LatestBackup = %v, want %v", firefox.LatestBackup, newer)
+	}
+
+	if chrome == nil {
+		t.Fatalf("expected a discovered Chrome profile at %s, got %+v", chromeProfile, profiles)
+	}
+	if chrome.Browser != BrowserChrome {
+		t.Errorf("Browser = %v, want %v", chrome.Browser, BrowserChrome)
+	}
+	if chrome.LatestBackup != filepath.Join(chromeProfile, "Bookmarks") {
+		t.Errorf("LatestBackup = %v, want the profile's Bookmarks file", chrome.LatestBackup)
+	}
+}
+
+func TestLoadLatestBackup_NoBackupFound(t *testing.T) {
+	profile := Profile{Browser: BrowserFirefox, Name: "no-backup"}
+	if _, err := LoadLatestBackup(profile); err == nil {
+		t.Error("expected an error when the profile has no LatestBackup")
+	}
+}
+
+func TestLoadLatestBackup_UnsupportedBrowser(t *testing.T) {
+	dir := t.TempDir()
+	bookmarksFile := filepath.Join(dir, "Bookmarks")
+	writeFile(t, bookmarksFile, sampleChromiumBookmarksJSON)
+
+	profile := Profile{Browser: BrowserChrome, Name: "Default", Path: dir, LatestBackup: bookmarksFile}
+	if _, err := LoadLatestBackup(profile); err == nil {
+		t.Error("expected an error for a non-Firefox profile, since bookmarks/sources depends on this package and not the reverse")
+	}
+}
+
+// sampleChromiumBookmarksJSON is a minimal Chromium "Bookmarks" file, used
+// only to populate a realistic-looking profile fixture.
+const sampleChromiumBookmarksJSON = `{
+  "roots": {
+    "bookmark_bar": {"type": "folder", "name": "Bookmarks Bar", "children": []},
+    "other": {"type": "folder", "name": "Other Bookmarks", "children": []},
+    "synced": {"type": "folder", "name": "Mobile Bookmarks", "children": []}
+  }
+}`
@@ -0,0 +1,302 @@
+package bookmarks
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"html"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Server serves a BookmarkData tree over HTTP: a browseable folder tree at
+// "/", drill-down into subfolders at "/folder/<path>", fulltext search at
+// "/search?q=", and format exports at "/export.<format>" via the Exporter
+// registry. It turns the tool from a one-shot converter into a lightweight
+// personal bookmark viewer usable from any device on the LAN.
+type Server struct {
+	mu   sync.RWMutex
+	data *BookmarkData
+
+	sourcePath string
+	authUser   string
+	authPass   string
+}
+
+// NewServer creates a Server that serves the bookmarks in sourcePath (a
+// .jsonlz4 or .json Firefox bookmark backup).
+func NewServer(sourcePath string) (*Server, error) {
+	s := &Server{sourcePath: sourcePath}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload re-reads sourcePath and swaps in the fresh BookmarkData tree. It is
+// safe to call concurrently with requests being served.
+func (s *Server) Reload() error {
+	data, err := NewBookmarkLoader().LoadBookmarksFromFile(s.sourcePath)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %w", s.sourcePath, err)
+	}
+
+	s.mu.Lock()
+	s.data = data
+	s.mu.Unlock()
+	return nil
+}
+
+// SetBasicAuth configures the HTTP Basic Auth credentials required to
+// access the server. Passing an empty user disables auth.
+func (s *Server) SetBasicAuth(user, pass string) {
+	s.authUser = user
+	s.authPass = pass
+}
+
+// Watch reloads sourcePath whenever it is written to, until ctx is
+// cancelled.
+func (s *Server) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(s.sourcePath)); err != nil {
+		return fmt.Errorf("error watching %s: %w", filepath.Dir(s.sourcePath), err)
+	}
+
+	target := filepath.Clean(s.sourcePath)
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != target {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := s.Reload(); err != nil {
+				fmt.Printf("Warning: failed to reload %s: %v\n", s.sourcePath, err)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("Warning: file watcher error: %v\n", err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Server) snapshot() *BookmarkData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data
+}
+
+// Handler returns an http.Handler serving the bookmark UI, wrapped with
+// Basic Auth if SetBasicAuth configured credentials.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleRoot)
+	mux.HandleFunc("/folder/", s.handleFolder)
+	mux.HandleFunc("/search", s.handleSearch)
+	mux.HandleFunc("/export.html", s.handleExport("html"))
+	mux.HandleFunc("/export.json", s.handleExport("json"))
+	mux.HandleFunc("/export.csv", s.handleExport("csv"))
+	mux.HandleFunc("/export.opml", s.handleExport("opml"))
+
+	if s.authUser == "" {
+		return mux
+	}
+	return s.requireBasicAuth(mux)
+}
+
+// requireBasicAuth wraps next with HTTP Basic Auth, comparing credentials
+// with subtle.ConstantTimeCompare so the comparison time does not depend on
+// their contents. It still returns early when the lengths differ.
+func (s *Server) requireBasicAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		validUser := subtle.ConstantTimeCompare([]byte(user), []byte(s.authUser)) == 1
+		validPass := subtle.ConstantTimeCompare([]byte(pass), []byte(s.authPass)) == 1
+		if !ok || !validUser || !validPass {
+			w.Header().Set("WWW-Authenticate", `Basic realm="firefox-bookmarks"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleRoot renders the root folder as a browseable HTML page.
+func (s *Server) handleRoot(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	root := s.snapshot()
+	writeFolderPage(w, root.Title, nil, root.Children)
+}
+
+// handleFolder drills into the subfolder named by the "/folder/<path>" URL,
+// where <path> is a "/"-separated list of folder titles.
+func (s *Server) handleFolder(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/folder/")
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	folder := findFolder(s.snapshot(), segments)
+	if folder == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeFolderPage(w, folder.Title, segments, folder.Children)
+}
+
+// findFolder walks down root by matching each segment against a child
+// folder's title, returning the BookmarkData at the end of the path, or nil
+// if any segment doesn't match.
+func findFolder(root *BookmarkData, segments []string) *BookmarkData {
+	current := root
+	for _, segment := range segments {
+		var next *BookmarkData
+		for i := range current.Children {
+			child := &current.Children[i]
+			if child.Children != nil && child.Title == segment {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// handleSearch performs case-insensitive fulltext search over titles, URIs,
+// tags and descriptions.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	var matches []BookmarkData
+	if query != "" {
+		matches = searchBookmarks(s.snapshot(), strings.ToLower(query))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html><head><title>Search</title></head><body>\n")
+	fmt.Fprintf(&sb, "<form action=\"/search\"><input name=\"q\" value=\"%s\"><button>Search</button></form>\n", html.EscapeString(query))
+	sb.WriteString("<ul>\n")
+	for _, bookmark := range matches {
+		fmt.Fprintf(&sb, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(bookmark.URI), html.EscapeString(bookmark.Title))
+	}
+	sb.WriteString("</ul>\n</body></html>\n")
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(sb.String()))
+}
+
+// searchBookmarks recursively collects the bookmarks under root whose
+// title, URI, tags or description contain query (already lowercased).
+func searchBookmarks(root *BookmarkData, query string) []BookmarkData {
+	var matches []BookmarkData
+
+	for _, child := range root.Children {
+		if child.TypeCode == BookmarkSeparatorType {
+			continue
+		}
+		if child.Children != nil {
+			matches = append(matches, searchBookmarks(&child, query)...)
+			continue
+		}
+		if bookmarkMatches(&child, query) {
+			matches = append(matches, child)
+		}
+	}
+
+	return matches
+}
+
+// bookmarkMatches reports whether data's title, URI, tags or description
+// contain query (already lowercased).
+func bookmarkMatches(data *BookmarkData, query string) bool {
+	if strings.Contains(strings.ToLower(data.Title), query) {
+		return true
+	}
+	if strings.Contains(strings.ToLower(data.URI), query) {
+		return true
+	}
+	for _, anno := range data.Annotations {
+		if anno.Name == netscapeDescriptionAnno || anno.Name == netscapeTagsAnno {
+			if strings.Contains(strings.ToLower(anno.Value), query) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// handleExport returns a handler that streams the server's current
+// bookmark data in the given format via the Exporter registry.
+func (s *Server) handleExport(format string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		exporter, err := ExporterForFormat(format)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := exporter.Export(w, s.snapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// writeFolderPage renders a directory-listing-style HTML page for a
+// folder's children, with breadcrumbs built from path.
+func writeFolderPage(w http.ResponseWriter, title string, path []string, children []BookmarkData) {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html><head><title>")
+	sb.WriteString(html.EscapeString(title))
+	sb.WriteString("</title></head><body>\n")
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(title))
+	sb.WriteString(`<form action="/search"><input name="q" placeholder="Search bookmarks"><button>Search</button></form>` + "\n")
+	sb.WriteString("<ul>\n")
+
+	if len(path) > 0 {
+		sb.WriteString(`<li><a href="..">..</a></li>` + "\n")
+	}
+
+	for _, child := range children {
+		switch {
+		case child.TypeCode == BookmarkSeparatorType:
+			continue
+		case child.Children != nil:
+			href := "/folder/" + strings.Join(append(append([]string{}, path...), child.Title), "/")
+			fmt.Fprintf(&sb, "<li><a href=\"%s\">%s/</a></li>\n", html.EscapeString(href), html.EscapeString(child.Title))
+		case child.URI != "":
+			fmt.Fprintf(&sb, "<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(child.URI), html.EscapeString(child.Title))
+		}
+	}
+
+	sb.WriteString("</ul>\n</body></html>\n")
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(sb.String()))
+}
@@ -0,0 +1,161 @@
+package bookmarks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sampleServerData() *BookmarkData {
+	return &BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title: "Development",
+				Children: []BookmarkData{
+					{Title: "GitHub", URI: "https://github.com"},
+					{
+						Title: "Go",
+						Annotations: []Annotation{
+							{Name: netscapeTagsAnno, Value: "golang,tools"},
+						},
+						URI: "https://go.dev",
+					},
+				},
+			},
+			{Title: "Example", URI: "https://example.com"},
+		},
+	}
+}
+
+func TestFindFolder(t *testing.T) {
+	root := sampleServerData()
+
+	if got := findFolder(root, nil); got != root {
+		t.Errorf("findFolder(nil) = %v, want root", got)
+	}
+
+	dev := findFolder(root, []string{"Development"})
+	if dev == nil || dev.Title != "Development" {
+		t.Fatalf("findFolder([Development]) = %+v, want Development folder", dev)
+	}
+
+	if got := findFolder(root, []string{"Missing"}); got != nil {
+		t.Errorf("findFolder([Missing]) = %+v, want nil", got)
+	}
+}
+
+func TestSearchBookmarks(t *testing.T) {
+	root := sampleServerData()
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"ByTitle", "github", []string{"GitHub"}},
+		{"ByURI", "example.com", []string{"Example"}},
+		{"ByTag", "golang", []string{"Go"}},
+		{"NoMatch", "nonexistent", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := searchBookmarks(root, tt.query)
+			var titles []string
+			for _, m := range matches {
+				titles = append(titles, m.Title)
+			}
+			if len(titles) != len(tt.want) {
+				t.Fatalf("searchBookmarks(%q) = %v, want %v", tt.query, titles, tt.want)
+			}
+			for i, title := range titles {
+				if title != tt.want[i] {
+					t.Errorf("searchBookmarks(%q)[%d] = %q, want %q", tt.query, i, title, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestServer_HandleRoot(t *testing.T) {
+	s := &Server{data: sampleServerData()}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "Development/") {
+		t.Errorf("expected Development folder link in body, got: %s", body)
+	}
+	if !strings.Contains(body, "https://example.com") {
+		t.Errorf("expected Example bookmark link in body, got: %s", body)
+	}
+}
+
+func TestServer_HandleFolder(t *testing.T) {
+	s := &Server{data: sampleServerData()}
+
+	req := httptest.NewRequest(http.MethodGet, "/folder/Development", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), "https://github.com") {
+		t.Errorf("expected GitHub bookmark link in body, got: %s", rec.Body.String())
+	}
+}
+
+func TestServer_HandleFolder_NotFound(t *testing.T) {
+	s := &Server{data: sampleServerData()}
+
+	req := httptest.NewRequest(http.MethodGet, "/folder/Missing", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestServer_HandleExport(t *testing.T) {
+	s := &Server{data: sampleServerData()}
+
+	req := httptest.NewRequest(http.MethodGet, "/export.json", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), `"url": "https://github.com"`) {
+		t.Errorf("expected JSON export in body, got: %s", rec.Body.String())
+	}
+}
+
+func TestServer_BasicAuth(t *testing.T) {
+	s := &Server{data: sampleServerData()}
+	s.SetBasicAuth("user", "pass")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status without credentials = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth("user", "pass")
+	rec = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status with credentials = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
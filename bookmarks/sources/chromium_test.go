@@ -0,0 +1,67 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleChromiumBookmarks = `{
+  "roots": {
+    "bookmark_bar": {
+      "type": "folder",
+      "name": "Bookmarks bar",
+      "date_added": "13312681200000000",
+      "children": [
+        {
+          "type": "url",
+          "name": "GitHub",
+          "url": "https://github.com",
+          "date_added": "13312681200000000",
+          "date_modified": "13312681200000000"
+        }
+      ]
+    },
+    "other": {"type": "folder", "name": "Other bookmarks", "children": []},
+    "synced": {"type": "folder", "name": "Mobile bookmarks", "children": []}
+  }
+}`
+
+func TestChromiumWebKitTimeToFirefox(t *testing.T) {
+	got := ChromiumWebKitTimeToFirefox("13312681200000000")
+	want := int64(13312681200000000 - webkitEpochOffsetMicros)
+	if got != want {
+		t.Errorf("ChromiumWebKitTimeToFirefox() = %v, want %v", got, want)
+	}
+
+	if got := ChromiumWebKitTimeToFirefox(""); got != 0 {
+		t.Errorf("ChromiumWebKitTimeToFirefox(\"\") = %v, want 0", got)
+	}
+}
+
+func TestLoadChromiumBookmarksFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Bookmarks")
+	if err := os.WriteFile(path, []byte(sampleChromiumBookmarks), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	data, err := LoadChromiumBookmarksFile(path)
+	if err != nil {
+		t.Fatalf("LoadChromiumBookmarksFile failed: %v", err)
+	}
+
+	if len(data.Children) != 3 {
+		t.Fatalf("Children length = %v, want %v", len(data.Children), 3)
+	}
+
+	bar := data.Children[0]
+	if bar.Title != "Bookmarks bar" {
+		t.Errorf("bar Title = %q, want %q", bar.Title, "Bookmarks bar")
+	}
+	if len(bar.Children) != 1 {
+		t.Fatalf("bar Children length = %v, want %v", len(bar.Children), 1)
+	}
+	if bar.Children[0].URI != "https://github.com" {
+		t.Errorf("bookmark URI = %q, want %q", bar.Children[0].URI, "https://github.com")
+	}
+}
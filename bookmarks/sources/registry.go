@@ -0,0 +1,118 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/panz3r/firefox-bookmarks/bookmarks"
+)
+
+// Loader normalizes a single browser's bookmark export into BookmarkData.
+type Loader interface {
+	// CanLoad reports whether this Loader recognizes path, by extension
+	// and/or file signature.
+	CanLoad(path string) bool
+	// Load reads and normalizes the bookmark tree at path.
+	Load(path string) (*bookmarks.BookmarkData, error)
+}
+
+// ChromeBookmarkLoader recognizes and loads Chrome/Chromium/Edge's
+// extensionless "Bookmarks" JSON profile file.
+type ChromeBookmarkLoader struct{}
+
+// NewChromeBookmarkLoader creates a new ChromeBookmarkLoader.
+func NewChromeBookmarkLoader() *ChromeBookmarkLoader {
+	return &ChromeBookmarkLoader{}
+}
+
+// CanLoad reports whether path looks like a Chromium "Bookmarks" file,
+// either by its conventional filename or by sniffing its JSON "roots" key.
+func (l *ChromeBookmarkLoader) CanLoad(path string) bool {
+	if filepath.Base(path) == "Bookmarks" {
+		return true
+	}
+	return hasSignature(path, `"roots"`)
+}
+
+// Load reads and normalizes the Chromium bookmarks file at path.
+func (l *ChromeBookmarkLoader) Load(path string) (*bookmarks.BookmarkData, error) {
+	return LoadChromiumBookmarksFile(path)
+}
+
+// SafariBookmarkLoader recognizes and loads Safari's Bookmarks.plist file.
+type SafariBookmarkLoader struct{}
+
+// NewSafariBookmarkLoader creates a new SafariBookmarkLoader.
+func NewSafariBookmarkLoader() *SafariBookmarkLoader {
+	return &SafariBookmarkLoader{}
+}
+
+// CanLoad reports whether path has a .plist extension.
+func (l *SafariBookmarkLoader) CanLoad(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".plist")
+}
+
+// Load reads and normalizes the Safari bookmarks plist at path.
+func (l *SafariBookmarkLoader) Load(path string) (*bookmarks.BookmarkData, error) {
+	return LoadSafariBookmarksPlist(path)
+}
+
+// hasSignature reports whether the first few KB of path's content contain
+// needle, used to recognize formats that don't carry a distinguishing
+// file extension.
+func hasSignature(path string, needle string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	buf := make([]byte, 4096)
+	n, _ := file.Read(buf)
+	return strings.Contains(string(buf[:n]), needle)
+}
+
+// LoaderRegistry picks a Loader for a file path by signature/extension, so
+// callers can support every registered browser's export format without
+// knowing ahead of time which one a given file is.
+type LoaderRegistry struct {
+	loaders []Loader
+}
+
+// NewLoaderRegistry creates a LoaderRegistry with the built-in Chrome and
+// Safari loaders already registered.
+func NewLoaderRegistry() *LoaderRegistry {
+	return &LoaderRegistry{
+		loaders: []Loader{NewChromeBookmarkLoader(), NewSafariBookmarkLoader()},
+	}
+}
+
+// Register adds loader to the registry, taking priority over loaders
+// already registered.
+func (r *LoaderRegistry) Register(loader Loader) {
+	r.loaders = append([]Loader{loader}, r.loaders...)
+}
+
+// CanLoad reports whether any registered Loader recognizes path.
+func (r *LoaderRegistry) CanLoad(path string) bool {
+	for _, loader := range r.loaders {
+		if loader.CanLoad(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Load finds the first registered Loader that recognizes path and uses it
+// to load and normalize the bookmark tree there, or returns an error if no
+// registered loader recognizes it.
+func (r *LoaderRegistry) Load(path string) (*bookmarks.BookmarkData, error) {
+	for _, loader := range r.loaders {
+		if loader.CanLoad(path) {
+			return loader.Load(path)
+		}
+	}
+	return nil, fmt.Errorf("no registered loader recognizes %s", path)
+}
@@ -0,0 +1,71 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChromeBookmarkLoader_CanLoad(t *testing.T) {
+	loader := NewChromeBookmarkLoader()
+
+	dir := t.TempDir()
+	named := filepath.Join(dir, "Bookmarks")
+	if err := os.WriteFile(named, []byte(sampleChromiumBookmarks), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if !loader.CanLoad(named) {
+		t.Error("expected CanLoad to recognize a file named Bookmarks")
+	}
+
+	sniffed := filepath.Join(dir, "backup.json")
+	if err := os.WriteFile(sniffed, []byte(sampleChromiumBookmarks), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if !loader.CanLoad(sniffed) {
+		t.Error("expected CanLoad to recognize Chromium JSON by its \"roots\" signature")
+	}
+
+	other := filepath.Join(dir, "other.json")
+	if err := os.WriteFile(other, []byte(`{"foo": "bar"}`), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if loader.CanLoad(other) {
+		t.Error("expected CanLoad to reject unrelated JSON")
+	}
+}
+
+func TestSafariBookmarkLoader_CanLoad(t *testing.T) {
+	loader := NewSafariBookmarkLoader()
+
+	if !loader.CanLoad("/profile/Bookmarks.plist") {
+		t.Error("expected CanLoad to recognize a .plist file")
+	}
+	if loader.CanLoad("/profile/Bookmarks.json") {
+		t.Error("expected CanLoad to reject a non-.plist file")
+	}
+}
+
+func TestLoaderRegistry_Load(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Bookmarks")
+	if err := os.WriteFile(path, []byte(sampleChromiumBookmarks), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	registry := NewLoaderRegistry()
+	data, err := registry.Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(data.Children) != 3 {
+		t.Errorf("Children length = %v, want %v", len(data.Children), 3)
+	}
+}
+
+func TestLoaderRegistry_Load_NoMatchingLoader(t *testing.T) {
+	registry := NewLoaderRegistry()
+	if _, err := registry.Load("/profile/places.sqlite"); err == nil {
+		t.Error("expected an error for a file no registered loader recognizes")
+	}
+}
@@ -0,0 +1,63 @@
+package sources
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/panz3r/firefox-bookmarks/bookmarks"
+	"howett.net/plist"
+)
+
+// safariNode mirrors a node in Safari's Bookmarks.plist file, where
+// WebBookmarkTypeList nodes are folders and WebBookmarkTypeLeaf nodes are
+// bookmarks.
+type safariNode struct {
+	WebBookmarkType string                 `plist:"WebBookmarkType"`
+	Title           string                 `plist:"Title"`
+	URLString       string                 `plist:"URLString"`
+	URIDictionary   map[string]interface{} `plist:"URIDictionary"`
+	Children        []safariNode           `plist:"Children"`
+}
+
+// safariNodeToBookmarkData converts a safariNode and its children into the
+// equivalent BookmarkData subtree.
+func safariNodeToBookmarkData(node safariNode) bookmarks.BookmarkData {
+	if node.WebBookmarkType == "WebBookmarkTypeLeaf" {
+		title := node.Title
+		if title == "" {
+			if t, ok := node.URIDictionary["title"].(string); ok {
+				title = t
+			}
+		}
+		return bookmarks.BookmarkData{Title: title, URI: node.URLString}
+	}
+
+	// WebBookmarkTypeList, and the implicit root node which has no type.
+	data := bookmarks.BookmarkData{Title: node.Title}
+	for _, child := range node.Children {
+		data.Children = append(data.Children, safariNodeToBookmarkData(child))
+	}
+	return data
+}
+
+// LoadSafariBookmarksPlist reads a Safari Bookmarks.plist file and
+// normalizes its WebBookmarkTypeList/WebBookmarkTypeLeaf tree into a
+// BookmarkData tree.
+func LoadSafariBookmarksPlist(path string) (*bookmarks.BookmarkData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening Safari bookmarks file: %w", err)
+	}
+	defer file.Close()
+
+	var root safariNode
+	if err := plist.NewDecoder(file).Decode(&root); err != nil {
+		return nil, fmt.Errorf("error parsing Safari bookmarks file: %w", err)
+	}
+
+	data := safariNodeToBookmarkData(root)
+	if data.Title == "" {
+		data.Title = "Bookmarks Menu"
+	}
+	return &data, nil
+}
@@ -0,0 +1,62 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"howett.net/plist"
+)
+
+func TestLoadSafariBookmarksPlist(t *testing.T) {
+	root := safariNode{
+		WebBookmarkType: "WebBookmarkTypeList",
+		Title:           "",
+		Children: []safariNode{
+			{
+				WebBookmarkType: "WebBookmarkTypeList",
+				Title:           "Favorites",
+				Children: []safariNode{
+					{
+						WebBookmarkType: "WebBookmarkTypeLeaf",
+						URLString:       "https://apple.com",
+						URIDictionary:   map[string]interface{}{"title": "Apple"},
+					},
+				},
+			},
+		},
+	}
+
+	data, err := plist.Marshal(root, plist.XMLFormat)
+	if err != nil {
+		t.Fatalf("Failed to marshal test plist: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "Bookmarks.plist")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	result, err := LoadSafariBookmarksPlist(path)
+	if err != nil {
+		t.Fatalf("LoadSafariBookmarksPlist failed: %v", err)
+	}
+
+	if len(result.Children) != 1 {
+		t.Fatalf("Children length = %v, want %v", len(result.Children), 1)
+	}
+
+	favorites := result.Children[0]
+	if favorites.Title != "Favorites" {
+		t.Errorf("favorites Title = %q, want %q", favorites.Title, "Favorites")
+	}
+	if len(favorites.Children) != 1 {
+		t.Fatalf("favorites Children length = %v, want %v", len(favorites.Children), 1)
+	}
+	if favorites.Children[0].Title != "Apple" {
+		t.Errorf("bookmark Title = %q, want %q", favorites.Children[0].Title, "Apple")
+	}
+	if favorites.Children[0].URI != "https://apple.com" {
+		t.Errorf("bookmark URI = %q, want %q", favorites.Children[0].URI, "https://apple.com")
+	}
+}
@@ -0,0 +1,43 @@
+// Package storage provides a safe, atomic write pipeline for generated
+// output files, so a crash or a write error never leaves a truncated or
+// corrupted file in place of one the user already had.
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// WriteAtomic calls produce with a writer into a temporary file created
+// alongside destPath, fsyncs it, and only renames it into place once
+// produce returns successfully. If produce returns an error, destPath is
+// left untouched and the temporary file is removed. If the process is
+// killed mid-write, destPath is also left untouched, but the temporary
+// file stays behind.
+func WriteAtomic(destPath string, produce func(io.Writer) error) error {
+	dir := filepath.Dir(destPath)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(destPath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below has succeeded
+
+	if err := produce(tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error producing %s: %w", destPath, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error syncing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error closing temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return fmt.Errorf("error renaming temp file into place: %w", err)
+	}
+	return nil
+}
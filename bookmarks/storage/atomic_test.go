@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteAtomic_WritesDestFile(t *testing.T) {
+	destPath := filepath.Join(t.TempDir(), "bookmarks.html")
+
+	err := WriteAtomic(destPath, func(w io.Writer) error {
+		_, err := w.Write([]byte("<html></html>"))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WriteAtomic failed: %v", err)
+	}
+
+	got, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("failed to read dest file: %v", err)
+	}
+	if string(got) != "<html></html>" {
+		t.Errorf("dest file content = %q, want %q", got, "<html></html>")
+	}
+}
+
+func TestWriteAtomic_DoesNotCreateExtraFilesOnSuccess(t *testing.T) {
+	dir := t.TempDir()
+	destPath := filepath.Join(dir, "bookmarks.html")
+
+	if err := WriteAtomic(destPath, func(w io.Writer) error {
+		_, err := w.Write([]byte("ok"))
+		return err
+	}); err != nil {
+		t.Fatalf("WriteAtomic failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "bookmarks.html" {
+		t.Errorf("expected only bookmarks.html in %s, got: %+v", dir, entries)
+	}
+}
+
+func TestWriteAtomic_LeavesExistingFileOnProduceError(t *testing.T) {
+	dir := t.TempDir()
+	destPath := filepath.Join(dir, "bookmarks.html")
+	if err := os.WriteFile(destPath, []byte("original"), 0644); err != nil {
+		t.Fatalf("failed to seed dest file: %v", err)
+	}
+
+	wantErr := errors.New("export failed")
+	err := WriteAtomic(destPath, func(w io.Writer) error {
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("WriteAtomic error = %v, want to wrap %v", err, wantErr)
+	}
+
+	got, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("failed to read dest file: %v", err)
+	}
+	if string(got) != "original" {
+		t.Errorf("dest file content = %q, want it untouched (%q)", got, "original")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected the failed write's temp file to be cleaned up, got: %+v", entries)
+	}
+}
+
+func TestWriteAtomic_PropagatesProduceErrorMessage(t *testing.T) {
+	destPath := filepath.Join(t.TempDir(), "bookmarks.html")
+
+	err := WriteAtomic(destPath, func(w io.Writer) error {
+		return fmt.Errorf("disk full")
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("WriteAtomic error = %q, want it to contain the produce error %q", err, "disk full")
+	}
+}
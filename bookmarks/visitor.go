@@ -0,0 +1,165 @@
+package bookmarks
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// bufioReaderPool reuses *bufio.Reader instances across VisitBookmarksJSON
+// calls, mirroring bufioWriterPool in converter_stream.go, so streaming many
+// bookmark backups in a row (e.g. one per profile returned by
+// DiscoverProfiles) doesn't keep allocating and discarding read buffers.
+var bufioReaderPool = sync.Pool{
+	New: func() interface{} {
+		return bufio.NewReaderSize(nil, 64*1024)
+	},
+}
+
+// BookmarkVisitor is called once for every node (folder or bookmark) found
+// while streaming a bookmark tree with VisitBookmarksJSON or
+// VisitBookmarksFromFile. path holds the titles of node's ancestors, root
+// first; depth is len(path). Returning a non-nil error aborts the walk and
+// is returned to the caller.
+//
+// A folder's children are visited before the folder itself: the folder's
+// own "dateAdded"/"lastModified" fields can appear in the source JSON after
+// its "children" array, so node isn't fully populated until its subtree has
+// already been walked. node.Children is always nil — subtree nodes are
+// delivered through their own visit calls instead of being retained on
+// their parent, which is what keeps memory use bounded by tree depth
+// rather than tree size.
+type BookmarkVisitor func(node *BookmarkData, path []string, depth int) error
+
+// VisitBookmarksJSON streams r's bookmark tree through visit without ever
+// materializing the whole tree in memory: only the current ancestor chain
+// (one BookmarkData per depth level) is held at a time, unlike
+// json.Decode(&BookmarkData{}), which builds the entire tree before
+// returning.
+func VisitBookmarksJSON(r io.Reader, visit BookmarkVisitor) error {
+	buffered := bufioReaderPool.Get().(*bufio.Reader)
+	buffered.Reset(r)
+	defer func() {
+		buffered.Reset(nil)
+		bufioReaderPool.Put(buffered)
+	}()
+
+	dec := json.NewDecoder(buffered)
+	_, err := visitBookmarkNode(dec, nil, visit)
+	return err
+}
+
+// VisitBookmarksFromFile streams filename's bookmark tree through visit,
+// auto-detecting the jsonlz4/JSON format the same way
+// BookmarkLoader.LoadBookmarksFromFile does.
+func (bl *BookmarkLoader) VisitBookmarksFromFile(filename string, visit BookmarkVisitor) error {
+	if bl.validator.IsValidJSONLZ4File(filename) {
+		file, err := os.Open(filename)
+		if err != nil {
+			return fmt.Errorf("file reading error: %w", err)
+		}
+		defer file.Close()
+
+		reader, err := bl.DecompressJSONLZ4Reader(file)
+		if err != nil {
+			return err
+		}
+		return VisitBookmarksJSON(reader, visit)
+	}
+
+	if bl.validator.IsJSONFile(filename) {
+		file, err := os.Open(filename)
+		if err != nil {
+			return fmt.Errorf("error opening file: %w", err)
+		}
+		defer file.Close()
+		return VisitBookmarksJSON(file, visit)
+	}
+
+	return fmt.Errorf("file '%s' is not a valid Firefox bookmark backup file (.jsonlz4) or JSON file", filename)
+}
+
+// visitBookmarkNode decodes a single JSON bookmark object from dec,
+// recursing into its "children" array (if any) before invoking visit on
+// the node itself.
+func visitBookmarkNode(dec *json.Decoder, path []string, visit BookmarkVisitor) (*BookmarkData, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	node := &BookmarkData{}
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error reading bookmark field name: %w", err)
+		}
+		key, ok := keyTok.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected non-string bookmark field name: %v", keyTok)
+		}
+
+		switch key {
+		case "title":
+			err = dec.Decode(&node.Title)
+		case "uri":
+			err = dec.Decode(&node.URI)
+		case "dateAdded":
+			err = dec.Decode(&node.DateAdded)
+		case "lastModified":
+			err = dec.Decode(&node.LastModified)
+		case "typeCode":
+			err = dec.Decode(&node.TypeCode)
+		case "annos":
+			err = dec.Decode(&node.Annotations)
+		case "children":
+			err = visitBookmarkChildren(dec, append(append([]string{}, path...), node.Title), visit)
+		default:
+			var discard interface{}
+			err = dec.Decode(&discard)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading bookmark field %q: %w", key, err)
+		}
+	}
+
+	if _, err := dec.Token(); err != nil { // consume closing '}'
+		return nil, err
+	}
+
+	if err := visit(node, path, len(path)); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// visitBookmarkChildren streams a "children" array, visiting each element
+// via visitBookmarkNode in turn.
+func visitBookmarkChildren(dec *json.Decoder, childPath []string, visit BookmarkVisitor) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		if _, err := visitBookmarkNode(dec, childPath, visit); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // consume closing ']'
+	return err
+}
+
+// expectDelim reads dec's next token and errors unless it is the JSON
+// delimiter want (one of '{', '}', '[', ']').
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || delim != want {
+		return fmt.Errorf("expected %q, got %v", want, tok)
+	}
+	return nil
+}
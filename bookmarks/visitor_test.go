@@ -0,0 +1,168 @@
+package bookmarks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sampleVisitorTree() BookmarkData {
+	return BookmarkData{
+		Title: "Bookmarks Menu",
+		Children: []BookmarkData{
+			{
+				Title: "Dev",
+				Children: []BookmarkData{
+					{Title: "GitHub", URI: "https://github.com", DateAdded: 100},
+				},
+				DateAdded: 50,
+			},
+			{Title: "Example", URI: "https://example.com", DateAdded: 200},
+		},
+	}
+}
+
+func TestVisitBookmarksJSON(t *testing.T) {
+	tree := sampleVisitorTree()
+	raw, err := json.Marshal(&tree)
+	if err != nil {
+		t.Fatalf("failed to marshal fixture: %v", err)
+	}
+
+	type visited struct {
+		title string
+		path  []string
+		depth int
+	}
+	var got []visited
+	visit := func(node *BookmarkData, path []string, depth int) error {
+		got = append(got, visited{title: node.Title, path: append([]string{}, path...), depth: depth})
+		if node.Children != nil {
+			t.Errorf("node %q: Children = %v, want nil", node.Title, node.Children)
+		}
+		return nil
+	}
+
+	if err := VisitBookmarksJSON(bytes.NewReader(raw), visit); err != nil {
+		t.Fatalf("VisitBookmarksJSON failed: %v", err)
+	}
+
+	// Children are visited before their parent folder, since a folder's
+	// own dateAdded/lastModified can appear in the JSON after "children".
+	wantTitles := []string{"GitHub", "Dev", "Example", "Bookmarks Menu"}
+	if len(got) != len(wantTitles) {
+		t.Fatalf("visited %d nodes, want %d: %+v", len(got), len(wantTitles), got)
+	}
+	for i, want := range wantTitles {
+		if got[i].title != want {
+			t.Errorf("got[%d].title = %q, want %q", i, got[i].title, want)
+		}
+	}
+
+	github := got[0]
+	if depth := github.depth; depth != 2 {
+		t.Errorf("GitHub depth = %d, want 2", depth)
+	}
+	if want := []string{"Bookmarks Menu", "Dev"}; !stringSlicesEqual(github.path, want) {
+		t.Errorf("GitHub path = %v, want %v", github.path, want)
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestVisitBookmarksJSON_VisitErrorAbortsWalk(t *testing.T) {
+	tree := sampleVisitorTree()
+	raw, err := json.Marshal(&tree)
+	if err != nil {
+		t.Fatalf("failed to marshal fixture: %v", err)
+	}
+
+	wantErr := errors.New("stop here")
+	calls := 0
+	visit := func(node *BookmarkData, path []string, depth int) error {
+		calls++
+		return wantErr
+	}
+
+	err = VisitBookmarksJSON(bytes.NewReader(raw), visit)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("VisitBookmarksJSON error = %v, want %v", err, wantErr)
+	}
+	if calls != 1 {
+		t.Errorf("visit was called %d times, want 1 (walk should abort on first error)", calls)
+	}
+}
+
+func TestBookmarkLoader_VisitBookmarksFromFile_JSONLZ4(t *testing.T) {
+	tree := sampleVisitorTree()
+	path := filepath.Join(t.TempDir(), "bookmarks.jsonlz4")
+	if err := WriteJSONLZ4File(path, &tree); err != nil {
+		t.Fatalf("WriteJSONLZ4File failed: %v", err)
+	}
+
+	var titles []string
+	visit := func(node *BookmarkData, path []string, depth int) error {
+		titles = append(titles, node.Title)
+		return nil
+	}
+
+	loader := NewBookmarkLoader()
+	if err := loader.VisitBookmarksFromFile(path, visit); err != nil {
+		t.Fatalf("VisitBookmarksFromFile failed: %v", err)
+	}
+	if len(titles) != 4 {
+		t.Fatalf("visited %d nodes, want 4: %v", len(titles), titles)
+	}
+}
+
+func TestBookmarkLoader_VisitBookmarksFromFile_JSON(t *testing.T) {
+	tree := sampleVisitorTree()
+	raw, err := json.Marshal(&tree)
+	if err != nil {
+		t.Fatalf("failed to marshal fixture: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "bookmarks.json")
+	writeFile(t, path, string(raw))
+
+	var titles []string
+	visit := func(node *BookmarkData, path []string, depth int) error {
+		titles = append(titles, node.Title)
+		return nil
+	}
+
+	loader := NewBookmarkLoader()
+	if err := loader.VisitBookmarksFromFile(path, visit); err != nil {
+		t.Fatalf("VisitBookmarksFromFile failed: %v", err)
+	}
+	if len(titles) != 4 {
+		t.Fatalf("visited %d nodes, want 4: %v", len(titles), titles)
+	}
+}
+
+func TestBookmarkLoader_VisitBookmarksFromFile_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not-json.txt")
+	writeFile(t, path, "this is not json")
+
+	loader := NewBookmarkLoader()
+	err := loader.VisitBookmarksFromFile(path, func(*BookmarkData, []string, int) error { return nil })
+	if err == nil {
+		t.Fatal("expected an error for a file that is neither jsonlz4 nor JSON")
+	}
+	if !strings.Contains(err.Error(), "not a valid") {
+		t.Errorf("error = %v, want a message about an unrecognized format", err)
+	}
+}
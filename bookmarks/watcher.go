@@ -0,0 +1,255 @@
+package bookmarks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// defaultWatcherDebounce is how long Watcher waits after the most recent
+// write event before loading a backup, so a multi-write save has time to
+// finish before it's read.
+const defaultWatcherDebounce = 2 * time.Second
+
+// WatcherCheckpoint records the most recently processed backup file, so a
+// Watcher that restarts doesn't redeliver backups it already processed.
+type WatcherCheckpoint struct {
+	LastPath    string    `json:"lastPath"`
+	LastModTime time.Time `json:"lastMtime"`
+	LastSize    int64     `json:"lastSize"`
+}
+
+// WatcherCallback receives a freshly parsed bookmark tree each time a
+// Watcher detects and loads a new backup file.
+type WatcherCallback func(data *BookmarkData, path string)
+
+// Watcher monitors a Firefox bookmarkbackups directory via fsnotify and
+// invokes a callback with a freshly parsed *BookmarkData whenever a new
+// .jsonlz4 backup appears. It debounces rapid successive writes and
+// remembers the last-processed file across restarts via a small on-disk
+// checkpoint file, so long-running sync daemons can be built on top of the
+// library without polling.
+type Watcher struct {
+	dir            string
+	checkpointPath string
+	debounce       time.Duration
+	onBackup       WatcherCallback
+
+	mu         sync.Mutex
+	checkpoint WatcherCheckpoint
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewWatcher creates a Watcher over dir (a bookmarkbackups directory),
+// calling onBackup with each new backup's parsed bookmark tree as it
+// appears. checkpointPath is where the watcher persists
+// {lastPath, lastMtime, lastSize} between runs: if it already exists, its
+// checkpoint is loaded so backups already processed in a previous run are
+// skipped; if it doesn't, Start establishes a baseline from whatever is
+// already in dir (an --end equivalent) so the first run only reacts to
+// backups written after it starts, rather than replaying every existing one.
+func NewWatcher(dir, checkpointPath string, onBackup WatcherCallback) (*Watcher, error) {
+	w := &Watcher{
+		dir:            dir,
+		checkpointPath: checkpointPath,
+		debounce:       defaultWatcherDebounce,
+		onBackup:       onBackup,
+	}
+	if err := w.loadCheckpoint(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// loadCheckpoint restores w.checkpoint from checkpointPath, leaving it zero
+// if the file doesn't exist yet.
+func (w *Watcher) loadCheckpoint() error {
+	data, err := os.ReadFile(w.checkpointPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading watcher checkpoint: %w", err)
+	}
+
+	var checkpoint WatcherCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return fmt.Errorf("error parsing watcher checkpoint: %w", err)
+	}
+	w.checkpoint = checkpoint
+	return nil
+}
+
+// saveCheckpoint persists checkpoint to w.checkpointPath.
+func (w *Watcher) saveCheckpoint(checkpoint WatcherCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("error encoding watcher checkpoint: %w", err)
+	}
+	if err := os.WriteFile(w.checkpointPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing watcher checkpoint: %w", err)
+	}
+	return nil
+}
+
+// establishBaseline records the most recent existing backup in dir as the
+// checkpoint, without delivering it to onBackup, if no checkpoint was
+// loaded from disk. This is what lets a first run skip every backup already
+// present and only react to new ones.
+func (w *Watcher) establishBaseline() error {
+	w.mu.Lock()
+	hasCheckpoint := w.checkpoint.LastPath != ""
+	w.mu.Unlock()
+	if hasCheckpoint {
+		return nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(w.dir, "*.jsonlz4"))
+	if err != nil || len(matches) == 0 {
+		return nil
+	}
+
+	latest := newestByModTime(matches)
+	info, err := os.Stat(latest)
+	if err != nil {
+		return nil
+	}
+
+	checkpoint := WatcherCheckpoint{LastPath: latest, LastModTime: info.ModTime(), LastSize: info.Size()}
+	w.mu.Lock()
+	w.checkpoint = checkpoint
+	w.mu.Unlock()
+	return w.saveCheckpoint(checkpoint)
+}
+
+// processIfNewer loads path and invokes onBackup, unless it already matches
+// the current checkpoint (same path, size and modification time).
+func (w *Watcher) processIfNewer(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error stating %s: %w", path, err)
+	}
+
+	w.mu.Lock()
+	unchanged := path == w.checkpoint.LastPath &&
+		info.Size() == w.checkpoint.LastSize &&
+		info.ModTime().Equal(w.checkpoint.LastModTime)
+	w.mu.Unlock()
+	if unchanged {
+		return nil
+	}
+
+	data, err := NewBookmarkLoader().LoadBookmarksFromFile(path)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %w", path, err)
+	}
+
+	checkpoint := WatcherCheckpoint{LastPath: path, LastModTime: info.ModTime(), LastSize: info.Size()}
+	w.mu.Lock()
+	w.checkpoint = checkpoint
+	w.mu.Unlock()
+	if err := w.saveCheckpoint(checkpoint); err != nil {
+		fmt.Printf("Warning: failed to persist watcher checkpoint: %v\n", err)
+	}
+
+	w.onBackup(data, path)
+	return nil
+}
+
+// Start watches dir until ctx is cancelled or Stop is called, blocking
+// until the watch loop exits. Run it in its own goroutine to use it as a
+// background daemon.
+func (w *Watcher) Start(ctx context.Context) error {
+	if err := w.establishBaseline(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	w.mu.Lock()
+	w.cancel = cancel
+	w.done = done
+	w.mu.Unlock()
+	defer close(done)
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating file watcher: %w", err)
+	}
+	defer fsWatcher.Close()
+
+	if err := fsWatcher.Add(w.dir); err != nil {
+		return fmt.Errorf("error watching %s: %w", w.dir, err)
+	}
+
+	var debounceTimer *time.Timer
+	var debounceChan <-chan time.Time
+	var candidate string
+
+	for {
+		select {
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if filepath.Ext(event.Name) != ".jsonlz4" {
+				continue
+			}
+
+			candidate = event.Name
+			if debounceTimer == nil {
+				debounceTimer = time.NewTimer(w.debounce)
+			} else {
+				if !debounceTimer.Stop() {
+					<-debounceTimer.C
+				}
+				debounceTimer.Reset(w.debounce)
+			}
+			debounceChan = debounceTimer.C
+
+		case <-debounceChan:
+			debounceChan = nil
+			debounceTimer = nil
+			if err := w.processIfNewer(candidate); err != nil {
+				fmt.Printf("Warning: failed to process %s: %v\n", candidate, err)
+			}
+
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("Warning: file watcher error: %v\n", err)
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Stop cancels a running Start call and waits for its watch loop to exit.
+// Start must already have been called (and not yet returned) for Stop to
+// have any effect.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	cancel := w.cancel
+	done := w.done
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	if done != nil {
+		<-done
+	}
+}
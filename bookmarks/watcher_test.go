@@ -0,0 +1,266 @@
+package bookmarks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewWatcher_NoExistingCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+
+	w, err := NewWatcher(dir, checkpointPath, func(data *BookmarkData, path string) {})
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+	if w.checkpoint.LastPath != "" {
+		t.Errorf("checkpoint.LastPath = %q, want empty with no checkpoint file", w.checkpoint.LastPath)
+	}
+}
+
+func TestNewWatcher_LoadsExistingCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+
+	want := WatcherCheckpoint{LastPath: "/backups/2026-01-01.jsonlz4", LastModTime: time.Now().UTC().Truncate(time.Second), LastSize: 42}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("failed to marshal checkpoint fixture: %v", err)
+	}
+	if err := os.WriteFile(checkpointPath, data, 0644); err != nil {
+		t.Fatalf("failed to write checkpoint fixture: %v", err)
+	}
+
+	w, err := NewWatcher(dir, checkpointPath, func(data *BookmarkData, path string) {})
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+	if w.checkpoint != want {
+		t.Errorf("checkpoint = %+v, want %+v", w.checkpoint, want)
+	}
+}
+
+func TestWatcher_EstablishBaseline_SkipsExistingBackups(t *testing.T) {
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+
+	existing := filepath.Join(dir, "2026-01-01.jsonlz4")
+	writeFile(t, existing, "not a real backup")
+
+	var calls int
+	w, err := NewWatcher(dir, checkpointPath, func(data *BookmarkData, path string) { calls++ })
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+	if err := w.establishBaseline(); err != nil {
+		t.Fatalf("establishBaseline failed: %v", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("expected no callback invocations from establishBaseline, got %d", calls)
+	}
+	if w.checkpoint.LastPath != existing {
+		t.Errorf("checkpoint.LastPath = %q, want %q", w.checkpoint.LastPath, existing)
+	}
+	if _, err := os.Stat(checkpointPath); err != nil {
+		t.Errorf("expected establishBaseline to persist a checkpoint file: %v", err)
+	}
+}
+
+func TestWatcher_EstablishBaseline_NoOpWhenCheckpointAlreadyLoaded(t *testing.T) {
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+	writeFile(t, filepath.Join(dir, "2026-01-01.jsonlz4"), "not a real backup")
+
+	want := WatcherCheckpoint{LastPath: "/already/processed.jsonlz4", LastModTime: time.Now().UTC().Truncate(time.Second), LastSize: 7}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("failed to marshal checkpoint fixture: %v", err)
+	}
+	if err := os.WriteFile(checkpointPath, data, 0644); err != nil {
+		t.Fatalf("failed to write checkpoint fixture: %v", err)
+	}
+
+	w, err := NewWatcher(dir, checkpointPath, func(data *BookmarkData, path string) {})
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+	if err := w.establishBaseline(); err != nil {
+		t.Fatalf("establishBaseline failed: %v", err)
+	}
+
+	if w.checkpoint != want {
+		t.Errorf("checkpoint = %+v, want it left untouched at %+v", w.checkpoint, want)
+	}
+}
+
+func TestWatcher_ProcessIfNewer_InvokesCallbackAndPersistsCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+	backupPath := filepath.Join(dir, "2026-01-02.jsonlz4")
+
+	sample := BookmarkData{Title: "Bookmarks Menu", Children: []BookmarkData{{Title: "Example", URI: "https://example.com"}}}
+	if err := WriteJSONLZ4File(backupPath, &sample); err != nil {
+		t.Fatalf("failed to write backup fixture: %v", err)
+	}
+
+	var got *BookmarkData
+	var gotPath string
+	w, err := NewWatcher(dir, checkpointPath, func(data *BookmarkData, path string) {
+		got = data
+		gotPath = path
+	})
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+
+	if err := w.processIfNewer(backupPath); err != nil {
+		t.Fatalf("processIfNewer failed: %v", err)
+	}
+
+	if got == nil || got.Title != "Bookmarks Menu" {
+		t.Fatalf("callback got %+v, want the parsed backup", got)
+	}
+	if gotPath != backupPath {
+		t.Errorf("callback path = %q, want %q", gotPath, backupPath)
+	}
+	if w.checkpoint.LastPath != backupPath {
+		t.Errorf("checkpoint.LastPath = %q, want %q", w.checkpoint.LastPath, backupPath)
+	}
+
+	persisted, err := os.ReadFile(checkpointPath)
+	if err != nil {
+		t.Fatalf("expected checkpoint file to be persisted: %v", err)
+	}
+	var checkpoint WatcherCheckpoint
+	if err := json.Unmarshal(persisted, &checkpoint); err != nil {
+		t.Fatalf("failed to parse persisted checkpoint: %v", err)
+	}
+	if checkpoint.LastPath != backupPath {
+		t.Errorf("persisted checkpoint.LastPath = %q, want %q", checkpoint.LastPath, backupPath)
+	}
+}
+
+func TestWatcher_ProcessIfNewer_SkipsUnchangedFile(t *testing.T) {
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+	backupPath := filepath.Join(dir, "2026-01-02.jsonlz4")
+
+	sample := BookmarkData{Title: "Bookmarks Menu"}
+	if err := WriteJSONLZ4File(backupPath, &sample); err != nil {
+		t.Fatalf("failed to write backup fixture: %v", err)
+	}
+
+	var calls int
+	w, err := NewWatcher(dir, checkpointPath, func(data *BookmarkData, path string) { calls++ })
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+
+	if err := w.processIfNewer(backupPath); err != nil {
+		t.Fatalf("processIfNewer failed: %v", err)
+	}
+	if err := w.processIfNewer(backupPath); err != nil {
+		t.Fatalf("second processIfNewer failed: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected exactly 1 callback invocation across both calls, got %d", calls)
+	}
+}
+
+func TestWatcher_StartStop_DeliversNewBackup(t *testing.T) {
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+
+	w, err := NewWatcher(dir, checkpointPath, nil)
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+	w.debounce = 20 * time.Millisecond
+
+	received := make(chan string, 1)
+	w.onBackup = func(data *BookmarkData, path string) { received <- path }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	startErr := make(chan error, 1)
+	go func() { startErr <- w.Start(ctx) }()
+
+	// Give fsnotify time to register the watch before writing the file.
+	time.Sleep(100 * time.Millisecond)
+
+	backupPath := filepath.Join(dir, "2026-01-03.jsonlz4")
+	sample := BookmarkData{Title: "Bookmarks Menu"}
+	if err := WriteJSONLZ4File(backupPath, &sample); err != nil {
+		t.Fatalf("failed to write backup fixture: %v", err)
+	}
+
+	select {
+	case path := <-received:
+		if path != backupPath {
+			t.Errorf("received callback for %q, want %q", path, backupPath)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Watcher to deliver the new backup")
+	}
+
+	w.Stop()
+	if err := <-startErr; err != nil {
+		t.Errorf("Start returned error: %v", err)
+	}
+}
+
+func TestWatcher_StartStop_DeliversSuccessiveBackups(t *testing.T) {
+	dir := t.TempDir()
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+
+	w, err := NewWatcher(dir, checkpointPath, nil)
+	if err != nil {
+		t.Fatalf("NewWatcher failed: %v", err)
+	}
+	w.debounce = 20 * time.Millisecond
+
+	received := make(chan string, 2)
+	w.onBackup = func(data *BookmarkData, path string) { received <- path }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	startErr := make(chan error, 1)
+	go func() { startErr <- w.Start(ctx) }()
+
+	// Give fsnotify time to register the watch before writing the files.
+	time.Sleep(100 * time.Millisecond)
+
+	sample := BookmarkData{Title: "Bookmarks Menu"}
+	for _, name := range []string{"2026-01-03.jsonlz4", "2026-01-04.jsonlz4"} {
+		path := filepath.Join(dir, name)
+		if err := WriteJSONLZ4File(path, &sample); err != nil {
+			t.Fatalf("failed to write backup fixture: %v", err)
+		}
+
+		select {
+		case got := <-received:
+			if got != path {
+				t.Errorf("received callback for %q, want %q", got, path)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for Watcher to deliver %s", path)
+		}
+
+		// Let the debounce timer fully settle before the next write, so
+		// each backup exercises a fresh timer rather than coalescing.
+		time.Sleep(w.debounce + 50*time.Millisecond)
+	}
+
+	w.Stop()
+	if err := <-startErr; err != nil {
+		t.Errorf("Start returned error: %v", err)
+	}
+}
@@ -2,314 +2,432 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
-	"html"
 	"io"
-	"math"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
-	"github.com/pierrec/lz4/v4"
+	"github.com/panz3r/firefox-bookmarks/bookmarks"
+	"github.com/panz3r/firefox-bookmarks/bookmarks/sources"
+	"github.com/panz3r/firefox-bookmarks/bookmarks/storage"
 )
 
-// Constants from the Python version
-const (
-	FirefoxLZ4Signature   = "mozLz4"
-	FirefoxLZ4HeaderSize  = 12
-	DefaultBufferSize     = 10 * 1024 * 1024 // 10MB
-	IndentSize            = 4
-	BookmarkSeparatorType = 3
-)
-
-// BookmarkData represents the structure of bookmark data
-type BookmarkData struct {
-	Title        string         `json:"title,omitempty"`
-	URI          string         `json:"uri,omitempty"`
-	Children     []BookmarkData `json:"children,omitempty"`
-	DateAdded    int64          `json:"dateAdded,omitempty"`
-	LastModified int64          `json:"lastModified,omitempty"`
-	TypeCode     int            `json:"typeCode,omitempty"`
-	Annos        []Annotation   `json:"annos,omitempty"`
+// formatFromExtension maps an output file extension to an export format
+// name, defaulting to "html" for unrecognized extensions.
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".csv":
+		return "csv"
+	case ".md", ".markdown":
+		return "md"
+	case ".opml":
+		return "opml"
+	case ".epub":
+		return "epub"
+	default:
+		return "html"
+	}
 }
 
-// Annotation represents bookmark annotations (like descriptions)
-type Annotation struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
-}
+// sourceRegistry recognizes non-Firefox bookmark export formats (Chrome,
+// Chromium, Edge, Safari) by extension or file signature. It's also
+// registered with bookmarks.RegisterExternalLoader below, so
+// BookmarkLoader.LoadBookmarksFromFile dispatches to it too.
+var sourceRegistry = sources.NewLoaderRegistry()
 
-// HTMLWriter wraps an io.Writer with indentation functionality
-type HTMLWriter struct {
-	writer io.Writer
+func init() {
+	bookmarks.RegisterExternalLoader(sourceRegistry)
 }
 
-// NewHTMLWriter creates a new HTMLWriter
-func NewHTMLWriter(w io.Writer) *HTMLWriter {
-	return &HTMLWriter{writer: w}
+// loadInputFile loads bookmarks from inputFile, auto-detecting Netscape
+// HTML and every format BookmarkLoader.LoadBookmarksFromFile recognizes
+// (Firefox's own jsonlz4/JSON, plus Chromium's "Bookmarks" JSON and
+// Safari's Bookmarks.plist via the registered sourceRegistry).
+func loadInputFile(inputFile string) (*bookmarks.BookmarkData, error) {
+	switch {
+	case strings.ToLower(filepath.Ext(inputFile)) == ".html":
+		htmlFile, err := os.Open(inputFile)
+		if err != nil {
+			return nil, err
+		}
+		defer htmlFile.Close()
+		return bookmarks.ImportFromNetscapeHTML(htmlFile)
+
+	default:
+		return bookmarks.NewBookmarkLoader().LoadBookmarksFromFile(inputFile)
+	}
 }
 
-// WriteIndented writes indented text to the output
-func (hw *HTMLWriter) WriteIndented(indent int, text string) error {
-	indentation := strings.Repeat(" ", IndentSize*indent)
-	_, err := fmt.Fprintf(hw.writer, "%s%s\n", indentation, text)
-	return err
+// writeExport renders data through exporter and writes it to outputPath via
+// storage.WriteAtomic, so a failed or interrupted export never clobbers a
+// file the user already had.
+func writeExport(outputPath string, exporter bookmarks.Exporter, data *bookmarks.BookmarkData) error {
+	return storage.WriteAtomic(outputPath, func(w io.Writer) error {
+		bufferedWriter := bufio.NewWriter(w)
+		if err := exporter.Export(bufferedWriter, data); err != nil {
+			return fmt.Errorf("error converting bookmarks: %w", err)
+		}
+		return bufferedWriter.Flush()
+	})
 }
 
-// htmlEscape escapes HTML special characters to prevent XSS and display issues
-func htmlEscape(text string) string {
-	if text == "" {
-		return ""
-	}
-	return html.EscapeString(text)
+// printUsage prints the usage information
+func printUsage() {
+	fmt.Printf(`
+ff_bookmarks [-o OUTPUT_FILE] [-f FORMAT] input_file...
+
+Converts Firefox bookmark backup files to HTML, JSON, CSV, Markdown, OPML or EPUB.
+Supports .jsonlz4 (compressed backup) and .json (uncompressed) Firefox backups,
+Netscape bookmark .html files, Chromium "Bookmarks" files and Safari .plist files.
+
+Examples:
+    ff_bookmarks bookmarks-2025-06-11.jsonlz4
+    ff_bookmarks -o my_bookmarks.html bookmarks-2025-06-11.jsonlz4
+    ff_bookmarks -f csv -o bookmarks.csv bookmarks.json
+    ff_bookmarks -merge -since 2025-01-01T00:00:00Z -o combined.html a.jsonlz4 b.jsonlz4
+    ff_bookmarks server -addr :8080 -watch bookmarks-2025-06-11.jsonlz4
+    ff_bookmarks merge a.jsonlz4 b.html c.json -o merged.html
+    ff_bookmarks discover
+    ff_bookmarks discover -latest abc123.default-release -o my_bookmarks.html
+    ff_bookmarks watch -o bookmarks.html ~/.mozilla/firefox/abc123.default-release/bookmarkbackups
+
+Options:
+`)
+	flag.PrintDefaults()
 }
 
-// isValidJSONLZ4File checks if the file is a valid Firefox jsonlz4 bookmark backup file
-func isValidJSONLZ4File(filename string) bool {
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		return false
+// runServer implements the "server" subcommand: it serves the bookmarks in
+// a .jsonlz4/.json backup over HTTP, optionally watching the file for
+// changes and/or requiring HTTP Basic Auth.
+func runServer(args []string) {
+	fs := flag.NewFlagSet("server", flag.ExitOnError)
+	var addr string
+	var watch bool
+	var auth string
+	fs.StringVar(&addr, "addr", ":8080", "Address to listen on")
+	fs.BoolVar(&watch, "watch", false, "Reload the source file when it changes on disk")
+	fs.StringVar(&auth, "auth", "", "Require HTTP Basic Auth, as user:pass")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: ff_bookmarks server [-addr :8080] [-watch] [-auth user:pass] bookmarks.jsonlz4\n")
+		os.Exit(1)
 	}
 
-	file, err := os.Open(filename)
+	srv, err := bookmarks.NewServer(fs.Arg(0))
 	if err != nil {
-		return false
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-	defer file.Close()
 
-	header := make([]byte, len(FirefoxLZ4Signature))
-	n, err := file.Read(header)
-	if err != nil || n != len(FirefoxLZ4Signature) {
-		return false
+	if auth != "" {
+		user, pass, ok := strings.Cut(auth, ":")
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: -auth must be in the form user:pass\n")
+			os.Exit(1)
+		}
+		srv.SetBasicAuth(user, pass)
 	}
 
-	return string(header) == FirefoxLZ4Signature
-}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-// isJSONFile checks if the file is a valid JSON file by trying to parse it
-func isJSONFile(filename string) bool {
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		return false
+	if watch {
+		go func() {
+			if err := srv.Watch(ctx); err != nil && err != context.Canceled {
+				fmt.Fprintf(os.Stderr, "Warning: file watcher stopped: %v\n", err)
+			}
+		}()
 	}
 
-	file, err := os.Open(filename)
-	if err != nil {
-		return false
+	fmt.Printf("Serving bookmarks from %s on %s\n", fs.Arg(0), addr)
+	httpServer := &http.Server{Addr: addr, Handler: srv.Handler()}
+	go func() {
+		<-ctx.Done()
+		httpServer.Close()
+	}()
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-	defer file.Close()
-
-	decoder := json.NewDecoder(file)
-	var data interface{}
-	return decoder.Decode(&data) == nil
 }
 
-// decompressJSONLZ4 decompresses a Firefox jsonlz4 bookmark backup file and returns the JSON data
-func decompressJSONLZ4(filename string) (*BookmarkData, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, fmt.Errorf("file reading error: %w", err)
+// runMerge implements the "merge" subcommand: it merges and deduplicates
+// several input files into one output, exercising the auto-format
+// detection in loadInputFile and the conflict strategies in
+// bookmarks.BookmarkMerger. Unlike the top-level flags, -o/-f/-strategy may
+// appear before or after the input files.
+func runMerge(args []string) {
+	var outputFile, strategy, format string
+	var inputFiles []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-o":
+			i++
+			if i >= len(args) {
+				fmt.Fprintf(os.Stderr, "Error: -o requires a value\n")
+				os.Exit(1)
+			}
+			outputFile = args[i]
+		case "-strategy":
+			i++
+			if i >= len(args) {
+				fmt.Fprintf(os.Stderr, "Error: -strategy requires a value\n")
+				os.Exit(1)
+			}
+			strategy = args[i]
+		case "-f", "-format":
+			i++
+			if i >= len(args) {
+				fmt.Fprintf(os.Stderr, "Error: %s requires a value\n", args[i-1])
+				os.Exit(1)
+			}
+			format = args[i]
+		default:
+			inputFiles = append(inputFiles, args[i])
+		}
 	}
-	defer file.Close()
 
-	// Skip the Firefox LZ4 header
-	_, err = file.Seek(FirefoxLZ4HeaderSize, 0)
-	if err != nil {
-		return nil, fmt.Errorf("error seeking past header: %w", err)
+	if len(inputFiles) < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: ff_bookmarks merge [-strategy merge|keep-first|keep-newest|rename] [-f FORMAT] [-o OUTPUT_FILE] input_file...\n")
+		os.Exit(1)
 	}
 
-	// Read the compressed data
-	compressedData, err := io.ReadAll(file)
-	if err != nil {
-		return nil, fmt.Errorf("error reading compressed data: %w", err)
+	var conflictStrategy bookmarks.ConflictStrategy
+	switch strategy {
+	case "", "merge":
+		conflictStrategy = bookmarks.MergeFolders
+	case "keep-first":
+		conflictStrategy = bookmarks.KeepFirst
+	case "keep-newest":
+		conflictStrategy = bookmarks.KeepNewest
+	case "rename":
+		conflictStrategy = bookmarks.Rename
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown -strategy %q\n", strategy)
+		os.Exit(1)
 	}
 
-	// Decompress the data
-	decompressedData := make([]byte, DefaultBufferSize)
-	n, err := lz4.UncompressBlock(compressedData, decompressedData)
-	if err != nil {
-		return nil, fmt.Errorf("LZ4 decompression error: %w", err)
+	trees := make([]*bookmarks.BookmarkData, 0, len(inputFiles))
+	for _, inputFile := range inputFiles {
+		if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: Input file '%s' does not exist.\n", inputFile)
+			os.Exit(1)
+		}
+		tree, err := loadInputFile(inputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Conversion failed: %v\n", err)
+			os.Exit(1)
+		}
+		trees = append(trees, tree)
 	}
 
-	// Parse JSON
-	var bookmarkData BookmarkData
-	err = json.Unmarshal(decompressedData[:n], &bookmarkData)
-	if err != nil {
-		return nil, fmt.Errorf("JSON parsing error: %w", err)
-	}
+	merged := bookmarks.NewBookmarkMerger(conflictStrategy).Merge(trees...)
 
-	return &bookmarkData, nil
-}
+	if format == "" {
+		if outputFile != "" {
+			format = formatFromExtension(outputFile)
+		} else {
+			format = "html"
+		}
+	}
+	if outputFile == "" {
+		outputFile = "merged." + format
+	}
 
-// loadJSONFile loads and parses a regular JSON file
-func loadJSONFile(filename string) (*BookmarkData, error) {
-	file, err := os.Open(filename)
+	exporter, err := bookmarks.ExporterForFormat(format)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file: %w", err)
+		fmt.Fprintf(os.Stderr, "Error: %v.\n", err)
+		os.Exit(1)
 	}
-	defer file.Close()
 
-	var bookmarkData BookmarkData
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&bookmarkData)
-	if err != nil {
-		return nil, fmt.Errorf("error loading JSON file: %w", err)
+	if err := writeExport(outputFile, exporter, merged); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	return &bookmarkData, nil
+	fmt.Printf("Successfully merged bookmarks to: %s\n", outputFile)
 }
 
-// convertFirefoxTimestamp converts Firefox timestamp to Unix timestamp string
-func convertFirefoxTimestamp(timestamp int64) string {
-	if timestamp == 0 {
-		return ""
+// runDiscover implements the "discover" subcommand: it lists the browser
+// profiles found on this machine, or, with -latest, converts the named
+// profile's most recent backup without the user having to locate the file
+// themselves.
+func runDiscover(args []string) {
+	fs := flag.NewFlagSet("discover", flag.ExitOnError)
+	var latest string
+	var outputFile, format string
+	fs.StringVar(&latest, "latest", "", "Convert the named profile's most recent backup instead of just listing profiles")
+	fs.StringVar(&outputFile, "o", "", "Path to output file when used with -latest")
+	fs.StringVar(&format, "f", "", "Output format when used with -latest: html, json, csv, md, opml or epub")
+	fs.Parse(args)
+
+	profiles, err := bookmarks.DiscoverProfiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Profile discovery failed: %v\n", err)
+		os.Exit(1)
+	}
+	if len(profiles) == 0 {
+		fmt.Println("No browser profiles found.")
+		return
 	}
-	return fmt.Sprintf("%d", int64(math.Floor(float64(timestamp)/1000000)))
-}
-
-// formatDateAttributes formats date attributes for HTML bookmark tags
-func formatDateAttributes(data *BookmarkData) string {
-	var attributes []string
 
-	if data.DateAdded != 0 {
-		dateAdded := convertFirefoxTimestamp(data.DateAdded)
-		if dateAdded != "" {
-			attributes = append(attributes, fmt.Sprintf(` ADD_DATE="%s"`, dateAdded))
+	if latest == "" {
+		for _, profile := range profiles {
+			backup := profile.LatestBackup
+			if backup == "" {
+				backup = "(no bookmark backup found)"
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n", profile.Browser, profile.Name, profile.Path, backup)
 		}
+		return
 	}
 
-	if data.LastModified != 0 {
-		lastModified := convertFirefoxTimestamp(data.LastModified)
-		if lastModified != "" {
-			attributes = append(attributes, fmt.Sprintf(` LAST_MODIFIED="%s"`, lastModified))
+	var selected *bookmarks.Profile
+	for i := range profiles {
+		if profiles[i].Name == latest {
+			selected = &profiles[i]
+			break
 		}
 	}
+	if selected == nil {
+		fmt.Fprintf(os.Stderr, "Error: No discovered profile named %q.\n", latest)
+		os.Exit(1)
+	}
 
-	return strings.Join(attributes, "")
-}
-
-// writeHTMLHeader writes the HTML document header
-func writeHTMLHeader(writer *HTMLWriter, title string) error {
-	header := fmt.Sprintf(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
-<!-- This is an automatically generated file.
-    It will be read and overwritten.
-    DO NOT EDIT! -->
-<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
-<TITLE>Bookmarks</TITLE>
-<H1>%s</H1>
-<DL><p>`, htmlEscape(title))
-
-	return writer.WriteIndented(0, header)
-}
-
-// writeFolder writes a bookmark folder to HTML
-func writeFolder(writer *HTMLWriter, data *BookmarkData, indent int) error {
-	title := htmlEscape(data.Title)
-	dateAttrs := formatDateAttributes(data)
-
-	err := writer.WriteIndented(indent, fmt.Sprintf(`<DT><H3%s>%s</H3>`, dateAttrs, title))
+	bookmarkData, err := bookmarks.LoadLatestBackup(*selected)
 	if err != nil {
-		return err
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-	return writer.WriteIndented(indent, `<DL><p>`)
-}
 
-// writeBookmark writes a single bookmark to HTML
-func writeBookmark(writer *HTMLWriter, data *BookmarkData, indent int) error {
-	uri := data.URI
-	title := data.Title
-	if title == "" {
-		title = uri
+	if format == "" {
+		if outputFile != "" {
+			format = formatFromExtension(outputFile)
+		} else {
+			format = "html"
+		}
+	}
+	if outputFile == "" {
+		outputFile = latest + "." + format
 	}
-	title = htmlEscape(title)
-	dateAttrs := formatDateAttributes(data)
 
-	err := writer.WriteIndented(indent,
-		fmt.Sprintf(`<DT><A HREF="%s"%s>%s</A>`, htmlEscape(uri), dateAttrs, title))
+	exporter, err := bookmarks.ExporterForFormat(format)
 	if err != nil {
-		return err
+		fmt.Fprintf(os.Stderr, "Error: %v.\n", err)
+		os.Exit(1)
 	}
 
-	// Handle bookmark descriptions
-	for _, anno := range data.Annos {
-		if anno.Name == "bookmarkProperties/description" {
-			description := htmlEscape(anno.Value)
-			err = writer.WriteIndented(indent, fmt.Sprintf(`<DD>%s`, description))
-			if err != nil {
-				return err
-			}
-		}
+	if err := writeExport(outputFile, exporter, bookmarkData); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	return nil
+	fmt.Printf("Successfully converted bookmarks to: %s\n", outputFile)
 }
 
-// convertBookmarksToHTML converts bookmark data to HTML format recursively
-func convertBookmarksToHTML(writer *HTMLWriter, data *BookmarkData, indent int) error {
-	// Handle containers (folders) with children
-	if data.Children != nil && len(data.Children) > 0 {
-		if indent == 0 {
-			// Output the main header
-			title := data.Title
-			if title == "" {
-				title = "Bookmarks Menu"
-			}
-			err := writeHTMLHeader(writer, title)
-			if err != nil {
-				return err
-			}
-		} else {
-			// Output a folder
-			err := writeFolder(writer, data, indent)
-			if err != nil {
-				return err
-			}
-		}
+// runWatch implements the "watch" subcommand: it watches a
+// bookmarkbackups directory for new backups and converts each one as it
+// appears, enabling a long-running sync daemon without polling.
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	var outputFile, format, checkpointFile string
+	fs.StringVar(&outputFile, "o", "", "Path to output file, overwritten on each new backup")
+	fs.StringVar(&format, "f", "", "Output format: html, json, csv, md, opml or epub. If omitted, inferred from the output file extension")
+	fs.StringVar(&checkpointFile, "checkpoint", "", "Path to the checkpoint file tracking the last-processed backup. Defaults to <dir>/.ff_bookmarks_watch_checkpoint.json")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: ff_bookmarks watch [-o OUTPUT_FILE] [-f FORMAT] bookmarkbackups_dir\n")
+		os.Exit(1)
+	}
+	dir := fs.Arg(0)
 
-		// Process children
-		for _, child := range data.Children {
-			// Skip separators (typeCode 3)
-			if child.TypeCode == BookmarkSeparatorType {
-				continue
-			}
-			err := convertBookmarksToHTML(writer, &child, indent+1)
-			if err != nil {
-				return err
-			}
+	if format == "" {
+		if outputFile != "" {
+			format = formatFromExtension(outputFile)
+		} else {
+			format = "html"
 		}
-
-		return writer.WriteIndented(indent, `</DL><p>`)
-	} else if data.URI != "" {
-		// Output a bookmark
-		return writeBookmark(writer, data, indent)
+	}
+	if outputFile == "" {
+		outputFile = "bookmarks." + format
+	}
+	if checkpointFile == "" {
+		checkpointFile = filepath.Join(dir, ".ff_bookmarks_watch_checkpoint.json")
 	}
 
-	return nil
-}
+	exporter, err := bookmarks.ExporterForFormat(format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v.\n", err)
+		os.Exit(1)
+	}
 
-// printUsage prints the usage information
-func printUsage() {
-	fmt.Printf(`
-ff_bookmarks [-o OUTPUT_FILE] input_file
+	onBackup := func(data *bookmarks.BookmarkData, path string) {
+		if err := writeExport(outputFile, exporter, data); err != nil {
+			fmt.Printf("Warning: failed to convert %s: %v\n", path, err)
+			return
+		}
+		fmt.Printf("Converted %s -> %s\n", path, outputFile)
+	}
 
-Converts Firefox bookmark backup files to HTML format.
-Supports both .jsonlz4 (compressed backup) and .json (uncompressed) input files.
+	watcher, err := bookmarks.NewWatcher(dir, checkpointFile, onBackup)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-Examples:
-    ff_bookmarks bookmarks-2025-06-11.jsonlz4
-    ff_bookmarks -o my_bookmarks.html bookmarks-2025-06-11.jsonlz4  
-    ff_bookmarks -o bookmarks.html bookmarks.json
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-Options:
-`)
-	flag.PrintDefaults()
+	fmt.Printf("Watching %s for new backups...\n", dir)
+	if err := watcher.Start(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "server" {
+		runServer(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		runMerge(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "discover" {
+		runDiscover(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		runWatch(os.Args[2:])
+		return
+	}
+
 	var outputFile string
+	var format string
+	var mergeMode bool
+	var since string
+	var favicons bool
 	var showHelp bool
 
-	flag.StringVar(&outputFile, "o", "", "Path to output HTML file. If omitted, uses input filename with .html extension")
+	flag.StringVar(&outputFile, "o", "", "Path to output file. If omitted, uses input filename (or \"merged\" with -merge) with the format's extension")
+	flag.StringVar(&format, "f", "", "Output format: html, json, csv, md, opml or epub. If omitted, inferred from the output file extension")
+	flag.StringVar(&format, "format", "", "Output format: html, json, csv, md, opml or epub. If omitted, inferred from the output file extension")
+	flag.BoolVar(&mergeMode, "merge", false, "Merge and deduplicate bookmarks from multiple input files into one output")
+	flag.StringVar(&since, "since", "", "Drop bookmarks added before this RFC3339 timestamp")
+	flag.BoolVar(&favicons, "favicons", false, "Fetch and embed favicons as ICON= attributes (html format only)")
 	flag.BoolVar(&showHelp, "help", false, "Show this help message")
 	flag.Usage = printUsage
 	flag.Parse()
@@ -319,73 +437,93 @@ func main() {
 		return
 	}
 
-	// Check if input file is provided
+	// Check that input file(s) are provided
 	if flag.NArg() < 1 {
 		fmt.Fprintf(os.Stderr, "Error: Input file is required.\n")
 		printUsage()
 		os.Exit(1)
 	}
+	if !mergeMode && flag.NArg() > 1 {
+		fmt.Fprintf(os.Stderr, "Error: Multiple input files require -merge.\n")
+		os.Exit(1)
+	}
 
-	inputFile := flag.Arg(0)
+	inputFiles := flag.Args()
+	for _, inputFile := range inputFiles {
+		if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: Input file '%s' does not exist.\n", inputFile)
+			os.Exit(1)
+		}
+	}
 
-	// Check if input file exists
-	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: Input file '%s' does not exist.\n", inputFile)
-		os.Exit(1)
+	// Determine output format, defaulting to the output file's extension
+	if format == "" {
+		if outputFile != "" {
+			format = formatFromExtension(outputFile)
+		} else {
+			format = "html"
+		}
+	}
+
+	var err error
+	var exporter bookmarks.Exporter
+	if favicons && format == "html" {
+		exporter = bookmarks.NewHTMLExporter(bookmarks.WithFavicons(bookmarks.NewHTTPFaviconFetcher(4)))
+	} else {
+		exporter, err = bookmarks.ExporterForFormat(format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v.\n", err)
+			os.Exit(1)
+		}
 	}
 
 	// Determine output filename
 	var outputPath string
 	if outputFile != "" {
 		outputPath = outputFile
+	} else if mergeMode {
+		outputPath = "merged." + format
 	} else {
-		ext := filepath.Ext(inputFile)
-		outputPath = strings.TrimSuffix(inputFile, ext) + ".html"
+		ext := filepath.Ext(inputFiles[0])
+		outputPath = strings.TrimSuffix(inputFiles[0], ext) + "." + format
 	}
 
-	// Determine file type and load data accordingly
-	var bookmarkData *BookmarkData
-	var err error
-
-	if isValidJSONLZ4File(inputFile) {
-		fmt.Printf("Processing Firefox jsonlz4 bookmark backup: %s\n", inputFile)
-		bookmarkData, err = decompressJSONLZ4(inputFile)
-	} else if isJSONFile(inputFile) {
-		fmt.Printf("Processing JSON bookmark file: %s\n", inputFile)
-		bookmarkData, err = loadJSONFile(inputFile)
+	// Load bookmark data, auto-detecting each input file's format, then
+	// merge them if requested
+	var bookmarkData *bookmarks.BookmarkData
+	if mergeMode {
+		trees := make([]*bookmarks.BookmarkData, 0, len(inputFiles))
+		for _, inputFile := range inputFiles {
+			tree, err := loadInputFile(inputFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: Conversion failed: %v\n", err)
+				os.Exit(1)
+			}
+			trees = append(trees, tree)
+		}
+		bookmarkData = bookmarks.MergeBookmarks(trees...)
 	} else {
-		fmt.Fprintf(os.Stderr, "Error: '%s' is not a valid Firefox bookmark backup file (.jsonlz4) or JSON file.\n", inputFile)
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Conversion failed: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Convert to HTML
-	fmt.Println("Converting bookmarks to HTML format...")
-	outputFileHandle, err := os.Create(outputPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to create output file: %v\n", err)
-		os.Exit(1)
-	}
-	defer outputFileHandle.Close()
-
-	writer := NewHTMLWriter(bufio.NewWriter(outputFileHandle))
-	err = convertBookmarksToHTML(writer, bookmarkData, 0)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to convert bookmarks: %v\n", err)
-		os.Exit(1)
+		bookmarkData, err = loadInputFile(inputFiles[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Conversion failed: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	// Flush the buffered writer
-	if bufferedWriter, ok := writer.writer.(*bufio.Writer); ok {
-		err = bufferedWriter.Flush()
+	if since != "" {
+		cutoff, err := time.Parse(time.RFC3339, since)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to flush output: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: Invalid -since timestamp: %v\n", err)
 			os.Exit(1)
 		}
+		bookmarkData = bookmarks.FilterSince(bookmarkData, cutoff)
+	}
+
+	// Export to the requested format
+	fmt.Printf("Converting bookmarks to %s format...\n", format)
+	if err := writeExport(outputPath, exporter, bookmarkData); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	fmt.Printf("Successfully converted bookmarks to: %s\n", outputPath)